@@ -45,6 +45,10 @@ func (p *Program) String() string {
 type Identifier struct {
 	Token token.Token
 	Value string
+
+	Resolved bool
+	Depth    int
+	Slot     int
 }
 
 func (i *Identifier) expressionNode()      {}
@@ -52,9 +56,10 @@ func (i *Identifier) TokenLiteral() string { return i.Token.Literal }
 func (i *Identifier) String() string       { return i.Value }
 
 type AssignStatement struct {
-	Token token.Token
-	Name  *Identifier
-	Value Expression
+	Token   token.Token
+	Name    *Identifier
+	Pattern Expression
+	Value   Expression
 }
 
 func (vs *AssignStatement) statementNode()       {}
@@ -62,7 +67,11 @@ func (vs *AssignStatement) TokenLiteral() string { return vs.Token.Literal }
 func (vs *AssignStatement) String() string {
 	var out bytes.Buffer
 	out.WriteString(vs.TokenLiteral() + " ")
-	out.WriteString(vs.Name.String())
+	if vs.Pattern != nil {
+		out.WriteString(vs.Pattern.String())
+	} else {
+		out.WriteString(vs.Name.String())
+	}
 	out.WriteString(" = ")
 	if vs.Value != nil {
 		out.WriteString(vs.Value.String())
@@ -123,7 +132,7 @@ func (pe *PrefixExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
 	out.WriteString(pe.Operator)
-	out.WriteString(pe.Token.Literal)
+	out.WriteString(pe.Right.String())
 	out.WriteString(")")
 	return out.String()
 }
@@ -210,10 +219,29 @@ func (ie *IfExpression) String() string {
 	return out.String()
 }
 
+type Parameter struct {
+	Target  Expression
+	Default Expression
+	Rest    bool
+}
+
+func (p *Parameter) String() string {
+	switch {
+	case p.Rest:
+		return "..." + p.Target.String()
+	case p.Default != nil:
+		return p.Target.String() + " = " + p.Default.String()
+	default:
+		return p.Target.String()
+	}
+}
+
 type FunctionLiteral struct {
-	Token      token.Token
-	Parameters []*Identifier
-	Body       *BlockStatement
+	Token       token.Token
+	Parameters  []*Parameter
+	Body        *BlockStatement
+	IsGenerator bool
+	IsArrow     bool
 }
 
 func (fl *FunctionLiteral) expressionNode()      {}
@@ -224,6 +252,19 @@ func (fl *FunctionLiteral) String() string {
 	for _, p := range fl.Parameters {
 		params = append(params, p.String())
 	}
+	if fl.IsArrow {
+		out.WriteString("(")
+		out.WriteString(strings.Join(params, ", "))
+		out.WriteString(") => ")
+		body := fl.Body.String()
+		if len(fl.Body.Statements) == 1 {
+			if ret, ok := fl.Body.Statements[0].(*ReturnStatement); ok && fl.Body.Token.Type == token.RETURN {
+				body = ret.ReturnValue.String()
+			}
+		}
+		out.WriteString(body)
+		return out.String()
+	}
 	out.WriteString(fl.TokenLiteral())
 	out.WriteString("(")
 	out.WriteString(strings.Join(params, ", "))
@@ -232,6 +273,61 @@ func (fl *FunctionLiteral) String() string {
 	return out.String()
 }
 
+type ConditionalExpression struct {
+	Token       token.Token
+	Condition   Expression
+	Consequence Expression
+	Alternative Expression
+}
+
+func (ce *ConditionalExpression) expressionNode()      {}
+func (ce *ConditionalExpression) TokenLiteral() string { return ce.Token.Literal }
+func (ce *ConditionalExpression) String() string {
+	return "(" + ce.Condition.String() + " ? " + ce.Consequence.String() + " : " + ce.Alternative.String() + ")"
+}
+
+type PipelineExpression struct {
+	Token token.Token
+	Left  Expression
+	Right Expression
+}
+
+func (pe *PipelineExpression) expressionNode()      {}
+func (pe *PipelineExpression) TokenLiteral() string { return pe.Token.Literal }
+func (pe *PipelineExpression) String() string {
+	return "(" + pe.Left.String() + " |> " + pe.Right.String() + ")"
+}
+
+type NamedArgument struct {
+	Token token.Token
+	Name  *Identifier
+	Value Expression
+}
+
+func (na *NamedArgument) expressionNode()      {}
+func (na *NamedArgument) TokenLiteral() string { return na.Token.Literal }
+func (na *NamedArgument) String() string       { return na.Name.String() + ": " + na.Value.String() }
+
+type SpreadExpression struct {
+	Token token.Token
+	Value Expression
+}
+
+func (se *SpreadExpression) expressionNode()      {}
+func (se *SpreadExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SpreadExpression) String() string       { return "..." + se.Value.String() }
+
+type YieldExpression struct {
+	Token token.Token
+	Value Expression
+}
+
+func (ye *YieldExpression) expressionNode()      {}
+func (ye *YieldExpression) TokenLiteral() string { return ye.Token.Literal }
+func (ye *YieldExpression) String() string {
+	return ye.TokenLiteral() + " " + ye.Value.String()
+}
+
 type CallExpression struct {
 	Token     token.Token
 	Function  Expression
@@ -282,9 +378,11 @@ func (al *ArrayLiteral) String() string {
 }
 
 type IndexExpression struct {
-	Token token.Token
-	Left  Expression
-	Index Expression
+	Token    token.Token
+	Left     Expression
+	Index    Expression
+	Optional bool
+	Member   bool
 }
 
 func (ie *IndexExpression) expressionNode()      {}
@@ -293,15 +391,66 @@ func (ie *IndexExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
 	out.WriteString(ie.Left.String())
+	if ie.Optional {
+		out.WriteString("?")
+	}
+	if ie.Member {
+		out.WriteString(".")
+		out.WriteString(ie.Index.String())
+		out.WriteString(")")
+		return out.String()
+	}
+	if ie.Optional {
+		out.WriteString(".")
+	}
 	out.WriteString("[")
 	out.WriteString(ie.Index.String())
 	out.WriteString("])")
 	return out.String()
 }
 
+type SliceExpression struct {
+	Token    token.Token
+	Left     Expression
+	Start    Expression
+	Stop     Expression
+	Step     Expression
+	Optional bool
+}
+
+func (se *SliceExpression) expressionNode()      {}
+func (se *SliceExpression) TokenLiteral() string { return se.Token.Literal }
+func (se *SliceExpression) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(se.Left.String())
+	if se.Optional {
+		out.WriteString("?.")
+	}
+	out.WriteString("[")
+	if se.Start != nil {
+		out.WriteString(se.Start.String())
+	}
+	out.WriteString(":")
+	if se.Stop != nil {
+		out.WriteString(se.Stop.String())
+	}
+	if se.Step != nil {
+		out.WriteString(":")
+		out.WriteString(se.Step.String())
+	}
+	out.WriteString("])")
+	return out.String()
+}
+
+type HashLiteralPair struct {
+	Key   Expression
+	Value Expression
+}
+
 type HashLiteral struct {
 	Token token.Token
-	Pairs map[Expression]Expression
+	Pairs []*HashLiteralPair
 }
 
 func (hl *HashLiteral) expressionNode()      {}
@@ -309,8 +458,8 @@ func (hl *HashLiteral) TokenLiteral() string { return hl.Token.Literal }
 func (hl *HashLiteral) String() string {
 	var out bytes.Buffer
 	pairs := []string{}
-	for key, value := range hl.Pairs {
-		pairs = append(pairs, key.String()+":"+value.String())
+	for _, pair := range hl.Pairs {
+		pairs = append(pairs, pair.Key.String()+":"+pair.Value.String())
 	}
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
@@ -318,6 +467,53 @@ func (hl *HashLiteral) String() string {
 	return out.String()
 }
 
+type ArrayPattern struct {
+	Token    token.Token
+	Elements []Expression
+	Rest     *Identifier
+}
+
+func (ap *ArrayPattern) expressionNode()      {}
+func (ap *ArrayPattern) TokenLiteral() string { return ap.Token.Literal }
+func (ap *ArrayPattern) String() string {
+	elements := []string{}
+	for _, el := range ap.Elements {
+		elements = append(elements, el.String())
+	}
+	if ap.Rest != nil {
+		elements = append(elements, "..."+strings.TrimPrefix(ap.Rest.String(), "_"))
+	}
+	return "[" + strings.Join(elements, ", ") + "]"
+}
+
+type HashPatternPair struct {
+	Key    string
+	Target Expression
+}
+
+type HashPattern struct {
+	Token token.Token
+	Pairs []*HashPatternPair
+	Rest  *Identifier
+}
+
+func (hp *HashPattern) expressionNode()      {}
+func (hp *HashPattern) TokenLiteral() string { return hp.Token.Literal }
+func (hp *HashPattern) String() string {
+	pairs := []string{}
+	for _, pair := range hp.Pairs {
+		if ident, ok := pair.Target.(*Identifier); ok && ident.Value == pair.Key {
+			pairs = append(pairs, pair.Key)
+		} else {
+			pairs = append(pairs, pair.Key+": "+pair.Target.String())
+		}
+	}
+	if hp.Rest != nil {
+		pairs = append(pairs, "..."+strings.TrimPrefix(hp.Rest.String(), "_"))
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
 type ForLoopExpression struct {
 	Token       token.Token
 	Condition   Expression
@@ -338,6 +534,7 @@ func (fle *ForLoopExpression) String() string {
 
 type PostfixExpression struct {
 	Token    token.Token
+	Name     *Identifier
 	Operator *StringLiteral
 }
 
@@ -353,8 +550,9 @@ func (pe *PostfixExpression) String() string {
 }
 
 type ImportStatement struct {
-	Token token.Token
-	Path  *StringLiteral
+	Token   token.Token
+	Path    *StringLiteral
+	Program *Program
 }
 
 func (is *ImportStatement) statementNode()       {}
@@ -379,6 +577,7 @@ type CaseExpression struct {
 	Token   token.Token
 	Default *Boolean
 	Expr    Expression
+	Pattern *VariantPattern
 	Block   *BlockStatement
 }
 
@@ -387,7 +586,7 @@ func (ce *CaseExpression) TokenLiteral() string { return ce.Token.Literal }
 func (ce *CaseExpression) String() string {
 	var out bytes.Buffer
 
-	if ce.Default.Token.Type == token.TRUE {
+	if ce.Default != nil {
 		out.WriteString("default ")
 	} else {
 		out.WriteString("case ")
@@ -421,10 +620,134 @@ func (se *SwitchExpression) String() string {
 	return out.String()
 }
 
+type EnumVariant struct {
+	Name   *Identifier
+	Fields []*Identifier
+}
+
+func (ev *EnumVariant) String() string {
+	if len(ev.Fields) == 0 {
+		return ev.Name.String()
+	}
+	fields := []string{}
+	for _, f := range ev.Fields {
+		fields = append(fields, f.String())
+	}
+	return ev.Name.String() + "(" + strings.Join(fields, ", ") + ")"
+}
+
+type EnumStatement struct {
+	Token    token.Token
+	Name     *Identifier
+	Variants []*EnumVariant
+}
+
+func (es *EnumStatement) statementNode()       {}
+func (es *EnumStatement) TokenLiteral() string { return es.Token.Literal }
+func (es *EnumStatement) String() string {
+	variants := []string{}
+	for _, v := range es.Variants {
+		variants = append(variants, v.String())
+	}
+	return es.TokenLiteral() + " " + es.Name.String() + " { " + strings.Join(variants, ", ") + " }"
+}
+
+type LiteralPattern struct {
+	Token token.Token
+	Value Expression
+}
+
+func (lp *LiteralPattern) expressionNode()      {}
+func (lp *LiteralPattern) TokenLiteral() string { return lp.Token.Literal }
+func (lp *LiteralPattern) String() string       { return lp.Value.String() }
+
+type RangePattern struct {
+	Token     token.Token
+	Low       Expression
+	High      Expression
+	Inclusive bool
+}
+
+func (rp *RangePattern) expressionNode()      {}
+func (rp *RangePattern) TokenLiteral() string { return rp.Token.Literal }
+func (rp *RangePattern) String() string {
+	if rp.Inclusive {
+		return rp.Low.String() + "..=" + rp.High.String()
+	}
+	return rp.Low.String() + ".." + rp.High.String()
+}
+
+type TypePattern struct {
+	Token    token.Token
+	Name     *Identifier
+	TypeName string
+}
+
+func (tp *TypePattern) expressionNode()      {}
+func (tp *TypePattern) TokenLiteral() string { return tp.Token.Literal }
+func (tp *TypePattern) String() string       { return tp.Name.String() + ": " + tp.TypeName }
+
+type VariantPattern struct {
+	Token   token.Token
+	Enum    *Identifier
+	Variant *Identifier
+	Fields  []Expression
+	Payload bool
+}
+
+func (vp *VariantPattern) expressionNode()      {}
+func (vp *VariantPattern) TokenLiteral() string { return vp.Token.Literal }
+func (vp *VariantPattern) String() string {
+	name := vp.Enum.String() + "." + vp.Variant.String()
+	if !vp.Payload {
+		return name
+	}
+	fields := []string{}
+	for _, field := range vp.Fields {
+		fields = append(fields, field.String())
+	}
+	return name + "(" + strings.Join(fields, ", ") + ")"
+}
+
+type MatchArm struct {
+	Pattern Expression
+	Guard   Expression
+	Body    *BlockStatement
+}
+
+func (ma *MatchArm) String() string {
+	var out bytes.Buffer
+	out.WriteString(ma.Pattern.String())
+	if ma.Guard != nil {
+		out.WriteString(" if ")
+		out.WriteString(ma.Guard.String())
+	}
+	out.WriteString(" => ")
+	out.WriteString(ma.Body.String())
+	return out.String()
+}
+
+type MatchExpression struct {
+	Token   token.Token
+	Subject Expression
+	Arms    []*MatchArm
+}
+
+func (me *MatchExpression) expressionNode()      {}
+func (me *MatchExpression) TokenLiteral() string { return me.Token.Literal }
+func (me *MatchExpression) String() string {
+	arms := []string{}
+	for _, arm := range me.Arms {
+		arms = append(arms, arm.String())
+	}
+	return "match (" + me.Subject.String() + ") {" + strings.Join(arms, ", ") + "}"
+}
+
 type ForeachStatement struct {
 	Token      token.Token
-	Index      *StringLiteral
-	Identifier *StringLiteral
+	Index      *Identifier
+	Identifier *Identifier
+	Pattern    Expression
 	Value      Expression
 	Body       *BlockStatement
 }
@@ -434,7 +757,14 @@ func (fes *ForeachStatement) TokenLiteral() string { return fes.Token.Literal }
 func (fes *ForeachStatement) String() string {
 	var out bytes.Buffer
 	out.WriteString("foreach ")
-	out.WriteString(fes.Identifier.Value)
+	if fes.Index != nil {
+		out.WriteString(fes.Index.Value + ", ")
+	}
+	if fes.Pattern != nil {
+		out.WriteString(fes.Pattern.String())
+	} else {
+		out.WriteString(fes.Identifier.Value)
+	}
 	out.WriteString(" ")
 	out.WriteString(fes.Value.String())
 	out.WriteString(fes.Body.String())
@@ -472,10 +802,20 @@ func (fl *FloatLiteral) expressionNode()      {}
 func (fl *FloatLiteral) TokenLiteral() string { return fl.Token.Literal }
 func (fl *FloatLiteral) String() string       { return fl.Token.Literal }
 
+type DecimalLiteral struct {
+	Token token.Token
+	Value string
+}
+
+func (dl *DecimalLiteral) expressionNode()      {}
+func (dl *DecimalLiteral) TokenLiteral() string { return dl.Token.Literal }
+func (dl *DecimalLiteral) String() string       { return dl.Token.Literal }
+
 type ObjectCallExpression struct {
-	Token  token.Token
-	Object Expression
-	Call   Expression
+	Token    token.Token
+	Object   Expression
+	Call     Expression
+	Optional bool
 }
 
 func (oce *ObjectCallExpression) expressionNode() {}
@@ -485,6 +825,9 @@ func (oce *ObjectCallExpression) TokenLiteral() string {
 func (oce *ObjectCallExpression) String() string {
 	var out bytes.Buffer
 	out.WriteString(oce.Object.String())
+	if oce.Optional {
+		out.WriteString("?")
+	}
 	out.WriteString(".")
 	out.WriteString(oce.Call.String())
 
@@ -18,6 +18,17 @@ func Modify(node Node, modifier ModifierFunc) Node {
 	case *IndexExpression:
 		node.Left, _ = Modify(node.Left, modifier).(Expression)
 		node.Index, _ = Modify(node.Index, modifier).(Expression)
+	case *SliceExpression:
+		node.Left, _ = Modify(node.Left, modifier).(Expression)
+		if node.Start != nil {
+			node.Start, _ = Modify(node.Start, modifier).(Expression)
+		}
+		if node.Stop != nil {
+			node.Stop, _ = Modify(node.Stop, modifier).(Expression)
+		}
+		if node.Step != nil {
+			node.Step, _ = Modify(node.Step, modifier).(Expression)
+		}
 	case *IfExpression:
 		node.Condition, _ = Modify(node.Condition, modifier).(Expression)
 		node.Consequence, _ = Modify(node.Consequence, modifier).(*BlockStatement)
@@ -36,12 +47,38 @@ func Modify(node Node, modifier ModifierFunc) Node {
 		}
 	case *ReturnStatement:
 		node.ReturnValue, _ = Modify(node.ReturnValue, modifier).(Expression)
+	case *YieldExpression:
+		node.Value, _ = Modify(node.Value, modifier).(Expression)
+	case *SpreadExpression:
+		node.Value, _ = Modify(node.Value, modifier).(Expression)
+	case *MatchExpression:
+		node.Subject, _ = Modify(node.Subject, modifier).(Expression)
+		for _, arm := range node.Arms {
+			if arm.Guard != nil {
+				arm.Guard, _ = Modify(arm.Guard, modifier).(Expression)
+			}
+			arm.Body, _ = Modify(arm.Body, modifier).(*BlockStatement)
+		}
+	case *ConditionalExpression:
+		node.Condition, _ = Modify(node.Condition, modifier).(Expression)
+		node.Consequence, _ = Modify(node.Consequence, modifier).(Expression)
+		node.Alternative, _ = Modify(node.Alternative, modifier).(Expression)
+	case *PipelineExpression:
+		node.Left, _ = Modify(node.Left, modifier).(Expression)
+		node.Right, _ = Modify(node.Right, modifier).(Expression)
+	case *NamedArgument:
+		node.Value, _ = Modify(node.Value, modifier).(Expression)
 	case *AssignStatement:
-		node.Name, _ = Modify(node.Name, modifier).(*Identifier)
+		if node.Name != nil {
+			node.Name, _ = Modify(node.Name, modifier).(*Identifier)
+		}
 		node.Value, _ = Modify(node.Value, modifier).(Expression)
 	case *FunctionLiteral:
 		for i := range node.Parameters {
-			node.Parameters[i], _ = Modify(node.Parameters[i], modifier).(*Identifier)
+			node.Parameters[i].Target, _ = Modify(node.Parameters[i].Target, modifier).(Expression)
+			if node.Parameters[i].Default != nil {
+				node.Parameters[i].Default, _ = Modify(node.Parameters[i].Default, modifier).(Expression)
+			}
 		}
 		node.Body, _ = Modify(node.Body, modifier).(*BlockStatement)
 	case *ArrayLiteral:
@@ -49,13 +86,10 @@ func Modify(node Node, modifier ModifierFunc) Node {
 			node.Elements[i], _ = Modify(node.Elements[i], modifier).(Expression)
 		}
 	case *HashLiteral:
-		newPairs := make(map[Expression]Expression)
-		for key, val := range node.Pairs {
-			newKey, _ := Modify(key, modifier).(Expression)
-			newVal, _ := Modify(val, modifier).(Expression)
-			newPairs[newKey] = newVal
+		for _, pair := range node.Pairs {
+			pair.Key, _ = Modify(pair.Key, modifier).(Expression)
+			pair.Value, _ = Modify(pair.Value, modifier).(Expression)
 		}
-		node.Pairs = newPairs
 	case *ForLoopExpression:
 		node.Condition, _ = Modify(node.Condition, modifier).(Expression)
 		node.Consequence, _ = Modify(node.Consequence, modifier).(*BlockStatement)
@@ -64,8 +98,9 @@ func Modify(node Node, modifier ModifierFunc) Node {
 	case *ImportStatement:
 		node.Path, _ = Modify(node.Path, modifier).(*StringLiteral)
 	case *CaseExpression:
-		node.Default, _ = Modify(node.Default, modifier).(*Boolean)
-		node.Expr = Modify(node.Expr, modifier).(Expression)
+		if node.Expr != nil {
+			node.Expr = Modify(node.Expr, modifier).(Expression)
+		}
 		node.Block = Modify(node.Block, modifier).(*BlockStatement)
 	case *SwitchExpression:
 		node.Value = Modify(node.Value, modifier).(Expression)
@@ -73,8 +108,8 @@ func Modify(node Node, modifier ModifierFunc) Node {
 			node.Choices[i] = Modify(node.Choices[i], modifier).(*CaseExpression)
 		}
 	case *ForeachStatement:
-		node.Index = Modify(node.Index, modifier).(*StringLiteral)
-		node.Identifier = Modify(node.Identifier, modifier).(*StringLiteral)
+		node.Index, _ = Modify(node.Index, modifier).(*Identifier)
+		node.Identifier, _ = Modify(node.Identifier, modifier).(*Identifier)
 		node.Value = Modify(node.Value, modifier).(Expression)
 		node.Body = Modify(node.Body, modifier).(*BlockStatement)
 	case *ObjectCallExpression:
@@ -0,0 +1,36 @@
+package evaluator
+
+import "testing"
+
+func TestHigherOrderArrayMethods(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println([3, 1, 2].map(func(x) { x * 2 }))", "[6, 2, 4]\n"},
+		{"println([3, 1, 2].filter(func(x) { x > 1 }))", "[3, 2]\n"},
+		{"println([3, 1, 2].reduce(func(acc, x) { acc + x }, 10))", "16\n"},
+		{"println([3, 1, 2].reduce(func(acc, x) { acc + x }))", "6\n"},
+		{"[3, 1].forEach(func(x) { println(x) })", "3\n1\n"},
+		{"println([3, 1, 2].any(func(x) { x > 2 }))", "true\n"},
+		{"println([3, 1, 2].all(func(x) { x > 2 }))", "false\n"},
+		{"println([[2, 1], [1, 2], [2, 3]].sortBy(func(a, b) { a[0] - b[0] }))", "[[1, 2], [2, 1], [2, 3]]\n"},
+		{"println([1, 2].flatMap(func(x) { [x, x] }))", "[1, 1, 2, 2]\n"},
+		{`println(["apple", "avocado", "banana"].groupBy(func(s) { s[0] }))`, "{a: [apple, avocado], b: [banana]}\n"},
+		{`println([1, 2, 3].zip(["a", "b"]))`, "[[1, a], [2, b]]\n"},
+		{"var double = func(x) { x * 2 }; println([1, 2].map(double))", "[2, 4]\n"},
+	})
+	testErrors(t, []outputTest{
+		{"[1].map(5)", "First argument to map() must be a function!"},
+		{"[].reduce(func(a, b) { a + b })", "reduce() of an empty array needs an initial value!"},
+		{"[1].map(func(a, b) { a })", "wrong number of arguments. got=1, want=2"},
+	})
+}
+
+func TestCallbackErrors(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println([1, 2].map(func(x) { return x / 0 }))\nprintln(\"next\")",
+			"Error: `division by zero: 1 / 0`\n\tat test.jak: 1\nnext\n"},
+		{"var f = func() { 1 / 0; println(\"still running\"); 5 }\nprintln(f())",
+			"Error: `division by zero: 1 / 0`\n\tat test.jak: 1\nstill running\n5\n"},
+		{"var ys = [1].map(func(x) { var y = x / 0; y })\nprintln(\"unreachable\")",
+			"Error: `division by zero: 1 / 0`\n\tat test.jak: 1\n"},
+	})
+}
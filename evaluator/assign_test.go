@@ -0,0 +1,40 @@
+package evaluator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMutAssignsDefiningScope(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var count = 0; var bump = func() { mut count = count + 1; }; bump(); bump(); println(count)", "2\n"},
+		{"var make = func() { var n = 0; return func() { mut n = n + 1; return n; }; }; var c = make(); c(); println(c())", "2\n"},
+		{"var make = func() { var n = 0; return func() { mut n = n + 1; return n; }; }; var a = make(); var b = make(); a(); a(); println(b())", "1\n"},
+		{"var g = 1; var outer = func() { var inner = func() { mut g = g + 1; }; inner(); }; outer(); println(g)", "2\n"},
+		{"var total = 0; foreach v in [1, 2, 3] { mut total = total + v; } println(total)", "6\n"},
+		{"var t = 0; if (true) { if (true) { mut t = 5; } } println(t)", "5\n"},
+		{"var a = 1; var b = 2; var swap = func() { mut [a, b] = [b, a]; }; swap(); println(a, b)", "2\n1\n"},
+		{"var n = 1; var f = func(n) { mut n = 5; return n; }; println(f(0), n)", "5\n1\n"},
+	})
+	testErrors(t, []outputTest{
+		{"mut missing = 1", "Variable `missing` not defined"},
+		{"var f = func() { mut missing = 1; }; f()", "Variable `missing` not defined"},
+		{"var f = func() { var local = 1; }; f(); mut local = 2", "Variable `local` not defined"},
+		{"mut [a, b] = [1, 2]", "Variable `a` not defined"},
+	})
+}
+
+func TestMutAssignsImportedBindings(t *testing.T) {
+	module := filepath.Join(t.TempDir(), "counter.jak")
+	source := "var count = 0; var bump = func() { mut count = count + 1; return count; };"
+	if err := os.WriteFile(module, []byte(source), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	use := fmt.Sprintf("use %q\n", module)
+	testOutput(t, []outputTest{
+		{use + "bump(); println(bump(), count)", "2\n2\n"},
+		{use + "mut count = 10; println(bump())", "11\n"},
+	})
+}
@@ -2,6 +2,8 @@ package evaluator
 
 import (
 	"fmt"
+	"math"
+	"math/big"
 	"os"
 	"strconv"
 	"strings"
@@ -11,6 +13,23 @@ import (
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
 )
 
+func decimalContextArgs(name string, token token.Token, args []object.Object, mode object.RoundingMode) (int, object.RoundingMode, *object.Error) {
+	scale, ok := args[0].(*object.Integer)
+	if !ok || scale.Value < 0 {
+		return 0, "", newError("scale given to `%s` must be a non-negative INTEGER, got %s", token, name, args[0].Inspect())
+	}
+	if len(args) == 2 {
+		str, ok := args[1].(*object.String)
+		if !ok {
+			return 0, "", newError("rounding mode given to `%s` must be STRING, got %s", token, name, args[1].Type())
+		}
+		if mode, ok = object.LookupRoundingMode(str.Value); !ok {
+			return 0, "", newError("unknown rounding mode given to `%s`: %s", token, name, str.Value)
+		}
+	}
+	return int(scale.Value), mode, nil
+}
+
 func reverseArray(elements []object.Object) []object.Object {
 	newElements := make([]object.Object, len(elements))
 	for i, j := 0, len(elements)-1; i < j; i, j = i+1, j-1 {
@@ -49,10 +68,16 @@ var builtins = map[string]*object.Builtin{
 			switch arg := args[0].(type) {
 			case *object.Array:
 				return &object.Integer{Value: int64(len(arg.Elements))}
+			case *object.Hash:
+				return &object.Integer{Value: int64(arg.Len())}
+			case *object.Range:
+				return &object.Integer{Value: arg.Len()}
 			case *object.String:
 				return &object.Integer{Value: int64(utf8.RuneCountInString(arg.Value))}
 			case *object.Integer:
 				return &object.Integer{Value: int64(len(strconv.Itoa(int(arg.Value))))}
+			case *object.BigInteger:
+				return &object.Integer{Value: int64(len(arg.Value.String()))}
 			default:
 				return newError("argument to `len` not supported, got %s", token,
 					args[0].Type())
@@ -75,6 +100,8 @@ var builtins = map[string]*object.Builtin{
 			case *object.Integer:
 				reversed := reverseInteger(arg.Value)
 				return &object.Integer{Value: reversed}
+			case *object.Range:
+				return arg.Reverse()
 			default:
 				return newError("argument to `reverse` not supported, got %s", token,
 					args[0].Type())
@@ -82,23 +109,13 @@ var builtins = map[string]*object.Builtin{
 		},
 	},
 	"print": {
-		Fn: func(token token.Token, args ...object.Object) object.Object {
-			for i, arg := range args {
-				if i == len(args)-1 {
-					fmt.Println(arg.Inspect())
-				} else {
-					fmt.Print(arg.Inspect())
-				}
-			}
-			return NULL
+		NamedFn: func(token token.Token, named map[string]object.Object, args ...object.Object) object.Object {
+			return printArguments(token, named, "", args)
 		},
 	},
 	"println": {
-		Fn: func(token token.Token, args ...object.Object) object.Object {
-			for _, arg := range args {
-				fmt.Println(arg.Inspect())
-			}
-			return NULL
+		NamedFn: func(token token.Token, named map[string]object.Object, args ...object.Object) object.Object {
+			return printArguments(token, named, "\n", args)
 		},
 	},
 	"input": {
@@ -134,18 +151,34 @@ var builtins = map[string]*object.Builtin{
 	},
 	"range": {
 		Fn: func(token token.Token, args ...object.Object) object.Object {
-			if len(args) != 1 {
-				return newError("wrong number of arguments. got=%d, want=1", token, len(args))
+			if len(args) < 1 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=1 to 3", token, len(args))
 			}
-			if args[0].Type() != object.INTEGER_OBJ {
-				return newError("argument to `range` must be INTEGER, got %s", token, args[0].Type())
+			bounds := make([]int64, len(args))
+			for i, arg := range args {
+				if arg.Type() != object.INTEGER_OBJ {
+					return newError("argument to `range` must be INTEGER, got %s", token, arg.Type())
+				}
+				integer, ok := arg.(*object.Integer)
+				if !ok {
+					return newError("argument to `range` is too large, got %s", token, arg.Inspect())
+				}
+				bounds[i] = integer.Value
+			}
+			rng := &object.Range{Start: 0, Stop: bounds[0], Step: 1}
+			switch len(bounds) {
+			case 2:
+				rng = &object.Range{Start: bounds[0], Stop: bounds[1], Step: 1}
+			case 3:
+				if bounds[2] == 0 {
+					return newError("step given to `range` cannot be zero", token)
+				}
+				rng = &object.Range{Start: bounds[0], Stop: bounds[1], Step: bounds[2]}
 			}
-			integer := args[0].(*object.Integer)
-			var elements []object.Object
-			for i := 0; i < int(integer.Value); i++ {
-				elements = append(elements, &object.Integer{Value: int64(i)})
+			if length := rng.Len(); length > object.MaxArrayLength {
+				return newError("`range` would produce %d elements, at most %d are allowed", token, length, object.MaxArrayLength)
 			}
-			return &object.Array{Elements: elements}
+			return rng.ToArray()
 		},
 	},
 	"typeof": {
@@ -156,6 +189,14 @@ var builtins = map[string]*object.Builtin{
 			return &object.String{Value: string(args[0].Type())}
 		},
 	},
+	"freeze": {
+		Fn: func(token token.Token, args ...object.Object) object.Object {
+			if len(args) != 1 {
+				return newError("wrong number of arguments. got=%d, want=1", token, len(args))
+			}
+			return object.Freeze(args[0])
+		},
+	},
 	"exit": {
 		Fn: func(token token.Token, args ...object.Object) object.Object {
 			if len(args) != 1 {
@@ -164,7 +205,10 @@ var builtins = map[string]*object.Builtin{
 			if args[0].Type() != object.INTEGER_OBJ {
 				return newError("argument to `exit` must be INTEGER, got %s", token, args[0].Type())
 			}
-			integer := args[0].(*object.Integer)
+			integer, ok := args[0].(*object.Integer)
+			if !ok {
+				return newError("argument to `exit` is too large, got %s", token, args[0].Inspect())
+			}
 			os.Exit(int(integer.Value))
 			return NULL
 		},
@@ -175,16 +219,22 @@ var builtins = map[string]*object.Builtin{
 				return newError("wrong number of arguments. got=%d, want=1", token, len(args))
 			}
 			switch arg := args[0].(type) {
-			case *object.Integer:
+			case *object.Integer, *object.BigInteger:
 				return arg
 			case *object.String:
-				integer, err := strconv.ParseInt(arg.Value, 10, 64)
-				if err != nil {
+				integer, ok := new(big.Int).SetString(arg.Value, 10)
+				if !ok {
 					return newError("could not convert string to integer", token)
 				}
-				return &object.Integer{Value: integer}
+				return bigIntegerToObject(integer)
 			case *object.Float:
-				return &object.Integer{Value: int64(arg.Value)}
+				if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+					return newError("could not convert float %s to integer", token, arg.Inspect())
+				}
+				integer, _ := big.NewFloat(arg.Value).Int(nil)
+				return bigIntegerToObject(integer)
+			case *object.Decimal:
+				return bigIntegerToObject(arg.Truncate())
 			default:
 				return newError("argument to `int` not supported, got %s", token, args[0].Type())
 			}
@@ -196,10 +246,13 @@ var builtins = map[string]*object.Builtin{
 				return newError("wrong number of arguments. got=%d, want=1", token, len(args))
 			}
 			switch arg := args[0].(type) {
-			case *object.Integer:
-				return &object.Float{Value: float64(arg.Value)}
+			case *object.Integer, *object.BigInteger:
+				return &object.Float{Value: integerToFloat(arg)}
 			case *object.Float:
 				return arg
+			case *object.Decimal:
+				float, _ := strconv.ParseFloat(arg.Inspect(), 64)
+				return &object.Float{Value: float}
 			case *object.String:
 				float, err := strconv.ParseFloat(arg.Value, 64)
 				if err != nil {
@@ -219,6 +272,10 @@ var builtins = map[string]*object.Builtin{
 			switch arg := args[0].(type) {
 			case *object.Integer:
 				return &object.String{Value: strconv.FormatInt(arg.Value, 10)}
+			case *object.BigInteger:
+				return &object.String{Value: arg.Value.String()}
+			case *object.Decimal:
+				return &object.String{Value: arg.Inspect()}
 			case *object.Float:
 				return &object.String{Value: strconv.FormatFloat(arg.Value, 'f', -1, 64)}
 			case *object.String:
@@ -228,6 +285,54 @@ var builtins = map[string]*object.Builtin{
 			}
 		},
 	},
+	"decimal": {
+		Fn: func(token token.Token, args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 3 {
+				return newError("wrong number of arguments. got=%d, want=1 to 3", token, len(args))
+			}
+			var decimal *object.Decimal
+			switch arg := args[0].(type) {
+			case *object.Decimal:
+				decimal = arg
+			case *object.Integer, *object.BigInteger:
+				decimal = object.NewDecimalFromInt(toBigInt(arg))
+			case *object.Float:
+				if math.IsNaN(arg.Value) || math.IsInf(arg.Value, 0) {
+					return newError("could not convert float %s to decimal", token, arg.Inspect())
+				}
+				decimal, _ = object.ParseDecimal(strconv.FormatFloat(arg.Value, 'f', -1, 64))
+			case *object.String:
+				parsed, err := object.ParseDecimal(strings.TrimSpace(arg.Value))
+				if err != nil {
+					return newError("could not convert string to decimal", token)
+				}
+				decimal = parsed
+			default:
+				return newError("argument to `decimal` not supported, got %s", token, args[0].Type())
+			}
+			if len(args) == 1 {
+				return decimal
+			}
+			scale, mode, err := decimalContextArgs("decimal", token, args[1:], object.DefaultDecimalContext.Mode)
+			if err != nil {
+				return err
+			}
+			return decimal.Rescale(scale, mode)
+		},
+	},
+	"decimalcontext": {
+		EnvFn: func(env *object.Environment, token token.Token, args ...object.Object) object.Object {
+			if len(args) < 1 || len(args) > 2 {
+				return newError("wrong number of arguments. got=%d, want=1 or 2", token, len(args))
+			}
+			scale, mode, err := decimalContextArgs("decimalcontext", token, args, env.DecimalContext().Mode)
+			if err != nil {
+				return err
+			}
+			env.SetDecimalContext(object.DecimalContext{Scale: scale, Mode: mode})
+			return NULL
+		},
+	},
 	"bool": {
 		Fn: func(token token.Token, args ...object.Object) object.Object {
 			if len(args) != 1 {
@@ -315,3 +420,35 @@ var builtins = map[string]*object.Builtin{
 		},
 	},
 }
+
+func IsBuiltin(name string) bool {
+	_, ok := builtins[name]
+	return ok
+}
+
+func printArguments(token token.Token, named map[string]object.Object, sep string, args []object.Object) object.Object {
+	end := "\n"
+	for _, name := range sortedNames(named) {
+		value, ok := named[name].(*object.String)
+		switch {
+		case name != "sep" && name != "end":
+			return newError("unknown named argument `%s`", token, name)
+		case !ok:
+			return newError("named argument `%s` must be STRING, got %s", token, name, named[name].Type())
+		case name == "sep":
+			sep = value.Value
+		default:
+			end = value.Value
+		}
+	}
+	if len(args) == 0 {
+		return NULL
+	}
+
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		parts[i] = arg.Inspect()
+	}
+	fmt.Print(strings.Join(parts, sep) + end)
+	return NULL
+}
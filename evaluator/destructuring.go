@@ -0,0 +1,159 @@
+package evaluator
+
+import (
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+type binding struct {
+	name  *ast.Identifier
+	value object.Object
+}
+
+func bindPattern(
+	pattern ast.Expression,
+	value object.Object,
+	env *object.Environment,
+	mode token.TokenType,
+	at token.Token,
+) *object.Error {
+	bindings, err := destructure(pattern, value, nil, at)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(bindings))
+	for _, b := range bindings {
+		if seen[b.name.Value] {
+			return newError("Variable `%s` bound more than once in %s", b.name.Token, b.name.Value, pattern.String())
+		}
+		seen[b.name.Value] = true
+		if mode == token.MUTATE && isConstBinding(b.name, env) {
+			return newError("Cannot assign to constant `%s`", b.name.Token, b.name.Value)
+		}
+		if b.name.Resolved {
+			continue
+		}
+
+		_, defined := env.Get(b.name.Value)
+		if (mode == token.VAR || mode == token.CONST) && defined {
+			return newError("Variable `%s` already defined", b.name.Token, b.name.Value)
+		}
+		if mode == token.MUTATE && !defined {
+			return newError("Variable `%s` not defined", b.name.Token, b.name.Value)
+		}
+	}
+
+	for _, b := range bindings {
+		if mode == token.MUTATE {
+			if !assignBinding(b.name, b.value, env) {
+				return newError("Variable `%s` not defined", b.name.Token, b.name.Value)
+			}
+		} else {
+			declareBinding(b.name, b.value, env, mode == token.CONST)
+		}
+	}
+	return nil
+}
+
+func destructure(target ast.Expression, value object.Object, bindings []binding, at token.Token) ([]binding, *object.Error) {
+	switch target := target.(type) {
+	case *ast.Identifier:
+		if target.Value == "_" {
+			return bindings, nil
+		}
+		return append(bindings, binding{name: target, value: value}), nil
+	case *ast.ArrayPattern:
+		return destructureArray(target, value, bindings)
+	case *ast.HashPattern:
+		return destructureHash(target, value, bindings)
+	default:
+		return nil, newError("cannot destructure into %s", at, target.String())
+	}
+}
+
+func destructureArray(pattern *ast.ArrayPattern, value object.Object, bindings []binding) ([]binding, *object.Error) {
+	iterable, ok := value.(object.Iterable)
+	if !ok {
+		return nil, newError("cannot destructure %s into %s", pattern.Token, value.Type(), pattern.String())
+	}
+
+	iterator := iterable.Iter()
+	defer iterator.Close()
+
+	var err *object.Error
+	for i, element := range pattern.Elements {
+		item, _, ok := iterator.Next()
+		if isError(item) {
+			return nil, item.(*object.Error)
+		}
+		if !ok {
+			return nil, newError("not enough values to destructure into %s: want %d, got %d",
+				pattern.Token, pattern.String(), len(pattern.Elements), i)
+		}
+		if bindings, err = destructure(element, item, bindings, pattern.Token); err != nil {
+			return nil, err
+		}
+	}
+
+	if pattern.Rest == nil {
+		item, _, ok := iterator.Next()
+		if isError(item) {
+			return nil, item.(*object.Error)
+		}
+		if ok {
+			return nil, newError("too many values to destructure into %s: want %d",
+				pattern.Token, pattern.String(), len(pattern.Elements))
+		}
+		return bindings, nil
+	}
+
+	rest := []object.Object{}
+	for {
+		item, _, ok := iterator.Next()
+		if isError(item) {
+			return nil, item.(*object.Error)
+		}
+		if !ok {
+			break
+		}
+		rest = append(rest, item)
+	}
+	return destructure(pattern.Rest, &object.Array{Elements: rest}, bindings, pattern.Token)
+}
+
+func destructureHash(pattern *ast.HashPattern, value object.Object, bindings []binding) ([]binding, *object.Error) {
+	hash, ok := value.(*object.Hash)
+	if !ok {
+		return nil, newError("cannot destructure %s into %s", pattern.Token, value.Type(), pattern.String())
+	}
+
+	used := make(map[object.HashKey]bool, len(pattern.Pairs))
+	var err *object.Error
+	for _, pair := range pattern.Pairs {
+		key := &object.String{Value: pair.Key}
+		hashKey := key.HashKey()
+		entry, ok := hash.Get(hashKey)
+		if !ok {
+			return nil, newError("key `%s` not found when destructuring %s", pattern.Token, pair.Key, pattern.String())
+		}
+		used[hashKey] = true
+		if bindings, err = destructure(pair.Target, entry.Value, bindings, pattern.Token); err != nil {
+			return nil, err
+		}
+	}
+
+	if pattern.Rest == nil {
+		return bindings, nil
+	}
+
+	rest := object.NewHash()
+	for _, entry := range hash.Pairs() {
+		hashKey := entry.Key.(object.Hashable).HashKey()
+		if !used[hashKey] {
+			rest.Set(hashKey, entry)
+		}
+	}
+	return destructure(pattern.Rest, rest, bindings, pattern.Token)
+}
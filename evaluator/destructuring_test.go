@@ -0,0 +1,47 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+func TestDestructuringDeclarations(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var [a, b, ...rest] = [1, 2, 3, 4]; println(a); println(b); println(rest)", "1\n2\n[3, 4]\n"},
+		{"var [a, ...rest] = [1]; println(rest)", "[]\n"},
+		{`var {name, age: years, ...others} = {"name": "x", "age": 3, "k": 1}; println(name); println(years); println(others)`,
+			"x\n3\n{k: 1}\n"},
+		{"var [[a, b], c] = [[1, 2], 3]; println(a + b + c)", "6\n"},
+		{"var [_, second] = [1, 2]; println(second)", "2\n"},
+		{"var [a, b] = 0..2; println(a + b)", "1\n"},
+		{"var x = 1; var y = 2; mut [x, y] = [y, x]; println(x); println(y)", "2\n1\n"},
+		{"foreach [k, v] in [[1, 2], [3, 4]] { println(k + v) }", "3\n7\n"},
+		{`var f = func([p, q], {r}) { p + q + r }; println(f([1, 2], {"r": 3}))`, "6\n"},
+	})
+	testErrors(t, []outputTest{
+		{"var [a, b] = [1]", "not enough values to destructure into [a, b]: want 2, got 1"},
+		{"var [a] = [1, 2]", "too many values to destructure into [a]: want 1"},
+		{`var {zz} = {"a": 1}`, "key `zz` not found when destructuring {zz}"},
+		{"var [a] = 5", "cannot destructure INTEGER into [a]"},
+		{`var f = func([p, q]) { p }; f(1)`, "cannot destructure INTEGER into [p, q]"},
+	})
+}
+
+func TestDestructuringErrorLocations(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var ok = 1\nvar [[a], b] = [5, 1]", "Error: `cannot destructure INTEGER into [a]`\n\tat test.jak: 2\n"},
+		{"var ok = 1\nvar {a: [b]} = {\"a\": 1}", "Error: `cannot destructure INTEGER into [b]`\n\tat test.jak: 2\n"},
+	})
+}
+
+func TestDestructureReportsEnclosingToken(t *testing.T) {
+	at := token.Token{Type: token.LBRACKET, Literal: "[", Line: 4}
+	literal := &ast.IntegerLiteral{Token: token.Token{Type: token.INT, Literal: "1"}, Value: 1}
+	_, err := destructure(literal, &object.Integer{Value: 1}, nil, at)
+	if err == nil || err.Token != at {
+		t.Fatalf("got %v, want an error at %v", err, at)
+	}
+}
@@ -0,0 +1,66 @@
+package evaluator
+
+import (
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+func evalEnumStatement(es *ast.EnumStatement, env *object.Environment) object.Object {
+	if !es.Name.Resolved {
+		if _, ok := env.Get(es.Name.Value); ok {
+			return newError("Variable `%s` already defined", es.Token, es.Name.Value)
+		}
+	}
+
+	enum := object.NewEnum(es.Name.Value)
+	for _, variant := range es.Variants {
+		fields := []string{}
+		for _, field := range variant.Fields {
+			fields = append(fields, field.Value)
+		}
+		enum.AddVariant(variant.Name.Value, fields)
+	}
+	declareBinding(es.Name, enum, env, false)
+	return NULL
+}
+
+func evalEnumIndexExpression(left, index object.Object, token token.Token) object.Object {
+	name, ok := index.(*object.String)
+	if !ok {
+		return newError("index operator not supported: %s[%s]", token, left.Type(), index.Type())
+	}
+
+	switch left := left.(type) {
+	case *object.Enum:
+		variant, ok := left.Variant(name.Value)
+		if !ok {
+			return newError("enum %s has no variant `%s`", token, left.Name, name.Value)
+		}
+		unit, ok := variant.Unit()
+		if !ok {
+			return newError("%s needs a payload: %s(...)", token, variant, variant)
+		}
+		return unit
+	case *object.EnumValue:
+		field, ok := left.Field(name.Value)
+		if !ok {
+			return newError("%s has no field `%s`", token, left.Variant, name.Value)
+		}
+		return field
+	default:
+		return newError("index operator not supported: %s", token, left.Type())
+	}
+}
+
+func enumValuesEqual(left, right *object.EnumValue, token token.Token) bool {
+	if left.Variant != right.Variant {
+		return false
+	}
+	for i := range left.Values {
+		if !valuesEqual(left.Values[i], right.Values[i], token) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package evaluator
+
+import "testing"
+
+func TestEnumMembers(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"enum Color { Red, Green }; println(Color.Green)", "Color.Green\n"},
+		{"enum Shape { Rect(w, h) }; println(Shape.Rect(2, 3).h)", "3\n"},
+		{"enum Shape { Rect(w, h) }; println(Shape.Rect(2, 3).values())", "[2, 3]\n"},
+		{"enum Shape { Rect(w, h) }; var s = null; println(s?.w)", "null\n"},
+		{"enum Color { Red, Green }; println(Color.Red == Color.Red, Color.Red == Color.Green)", "true\nfalse\n"},
+		{`var h = {"a": 1}; println(h.a)`, "1\n"},
+	})
+	testErrors(t, []outputTest{
+		{"var xs = [1]; xs.size", "index operator not supported: ARRAY"},
+		{"enum Color { Red }; Color.Blue", "enum Color has no variant `Blue`"},
+		{"enum Shape { Rect(w, h) }; Shape.Rect", "Shape.Rect needs a payload: Shape.Rect(...)"},
+		{"enum Shape { Rect(w, h) }; Shape.Rect(1, 2).r", "Shape.Rect has no field `r`"},
+		{"var xs = [1]; xs.match()", "Failed to invoke method: match"},
+	})
+}
+
+func TestEnumMatchPatterns(t *testing.T) {
+	shapes := "enum Shape { Circle(r), Rect(w, h), Empty }; enum Opt { Some(v), None }; "
+	testOutput(t, []outputTest{
+		{shapes + "println(match (Shape.Circle(2)) { Shape.Rect(w, h) => w * h, Shape.Circle(r) => r * r })", "4\n"},
+		{shapes + "println(match (Shape.Rect(2, 3)) { Shape.Rect(w, _) => w })", "2\n"},
+		{shapes + `println(match (Shape.Empty) { Shape.Circle(r) => r, Shape.Empty => "empty" })`, "empty\n"},
+		{shapes + "println(match (Opt.Some(Shape.Circle(1))) { Opt.Some(Shape.Rect(w, h)) => w, Opt.Some(Shape.Circle(r)) => r })", "1\n"},
+		{shapes + `println(match (Opt.Some(5)) { Opt.Some(1..=3) => "small", Opt.Some(n: INTEGER) => n })`, "5\n"},
+		{shapes + `println(match (Opt.Some(5)) { Opt.Some(n) if n > 9 => "big", Opt.Some(n) => n })`, "5\n"},
+		{shapes + `println(match ([Opt.None, Opt.Some(2)]) { [Opt.None, Opt.Some(v)] => v })`, "2\n"},
+		{shapes + `println(match (1) { Opt.None => "none", _ => "not an enum value" })`, "not an enum value\n"},
+	})
+	testErrors(t, []outputTest{
+		{shapes + "match (1) { Shape.Square => 1 }", "enum Shape has no variant `Square`"},
+		{shapes + "match (1) { Shape.Empty(x) => 1 }", "Shape.Empty has no payload, match it without parentheses"},
+		{shapes + "match (1) { Shape.Circle => 1 }", "Shape.Circle needs a payload: Shape.Circle(...)"},
+		{shapes + "match (1) { Shape.Rect(w) => 1 }", "pattern Shape.Rect takes 2 payload value(s), got 1"},
+		{"var xs = []; match (1) { xs.Circle(r) => 1 }", "xs is not an enum: ARRAY"},
+		{shapes + "match (Shape.Rect(1, 1)) { Shape.Rect(a, a) => a }", "Variable `a` bound more than once in Shape.Rect(a, a)"},
+	})
+}
+
+func TestEnumSwitchPatterns(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`enum Shape { Circle(r), Rect(w, h) }; switch (Shape.Rect(2, 3)) { case Shape.Circle(r) { println(r) } case Shape.Rect(w, h) { println(w * h) } }`, "6\n"},
+		{`enum Opt { Some(v), None }; switch (Opt.Some(2)) { case Opt.Some(1) { println("one") } case Opt.Some(n) { println(n) } }`, "2\n"},
+		{`enum Opt { Some(v) }; enum Color { Red, Green }; switch (Opt.Some(Color.Green)) { case Opt.Some(Color.Red) { println("red") } case Opt.Some(Color.Green) { println("green") } }`, "green\n"},
+		{`var xs = [4, 5]; switch (1) { case xs.find(5) { println("found") } }`, "found\n"},
+	})
+	testErrors(t, []outputTest{
+		{"enum Shape { Rect(w, h) }; switch (Shape.Rect(1, 1)) { case Shape.Rect(a, a) { a } }", "Variable `a` bound more than once in Shape.Rect(a, a)"},
+		{"enum Shape { Rect(w, h) }; switch (1) { case Shape.Rect(w) { w } }", "pattern Shape.Rect takes 2 payload value(s), got 1"},
+	})
+}
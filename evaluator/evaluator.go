@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"math/big"
 	"os"
+	"sort"
 
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/file"
@@ -32,7 +34,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		}
 	case *ast.ExpressionStatement:
 		res := Eval(node.Expression, env)
-		if isError(res) {
+		if isError(res) && res != GENERATOR_CLOSED {
 			fmt.Fprintf(os.Stderr, "%s\n", res.Inspect())
 			return NULL
 		} else {
@@ -50,11 +52,17 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		if isError(left) {
 			return left
 		}
+		if node.Operator == "??" {
+			if left.Type() != object.NULL_OBJ {
+				return left
+			}
+			return Eval(node.Right, env)
+		}
 		right := Eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		return evalInfixExpression(node.Operator, left, right, node.Token)
+		return evalInfixExpression(node.Operator, left, right, env, node.Token)
 	case *ast.BlockStatement:
 		return evalBlockStatement(node, env)
 	case *ast.IfExpression:
@@ -76,7 +84,26 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	case *ast.FunctionLiteral:
 		params := node.Parameters
 		body := node.Body
-		return &object.Function{Parameters: params, Env: env, Body: body}
+		return &object.Function{Parameters: params, Env: env, Body: body, IsGenerator: node.IsGenerator}
+	case *ast.YieldExpression:
+		return evalYieldExpression(node, env)
+	case *ast.SpreadExpression:
+		return newError("spread is only allowed in call arguments and array literals", node.Token)
+	case *ast.MatchExpression:
+		return evalMatchExpression(node, env)
+	case *ast.ConditionalExpression:
+		condition := Eval(node.Condition, env)
+		if isError(condition) {
+			return condition
+		}
+		if isTruthy(condition) {
+			return Eval(node.Consequence, env)
+		}
+		return Eval(node.Alternative, env)
+	case *ast.PipelineExpression:
+		return evalPipelineExpression(node, env)
+	case *ast.NamedArgument:
+		return newError("named argument `%s` is only allowed in function calls", node.Token, node.Name.Value)
 	case *ast.CallExpression:
 		if node.Function.TokenLiteral() == "quote" {
 			if len(node.Arguments) != 1 {
@@ -88,18 +115,7 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 			}
 			return quote(node.Arguments[0], env)
 		}
-
-		function := Eval(node.Function, env)
-		if isError(function) {
-			return function
-		}
-
-		args := evalExpressions(node.Arguments, env)
-		if len(args) == 1 && isError(args[0]) {
-			return args[0]
-		}
-
-		return applyFunction(function, args, node.Token)
+		return evalChainExpression(node, env)
 	case *ast.StringLiteral:
 		return &object.String{Value: node.Value}
 	case *ast.ArrayLiteral:
@@ -109,21 +125,17 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		}
 		return &object.Array{Elements: elements}
 	case *ast.IndexExpression:
-		left := Eval(node.Left, env)
-		if isError(left) {
-			return left
-		}
-		index := Eval(node.Index, env)
-		if isError(index) {
-			return index
-		}
-		return evalIndexExpression(left, index, node.Token)
+		return evalChainExpression(node, env)
+	case *ast.SliceExpression:
+		return evalChainExpression(node, env)
 	case *ast.HashLiteral:
 		return evalHashLiteral(node, env, node.Token)
 	case *ast.ForLoopExpression:
 		return evalForLoopExpression(node, env)
 	case *ast.PostfixExpression:
 		return evalPostfixExpression(env, node.Operator.Value, node, node.Token)
+	case *ast.EnumStatement:
+		return evalEnumStatement(node, env)
 	case *ast.ImportStatement:
 		evalImportStatement(node, env)
 	case *ast.NullLiteral:
@@ -134,9 +146,14 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 		return evalForeachExpression(node, env, node.Token)
 	case *ast.FloatLiteral:
 		return &object.Float{Value: node.Value}
+	case *ast.DecimalLiteral:
+		value, err := object.ParseDecimal(node.Value)
+		if err != nil {
+			return newError("could not parse %q as decimal", node.Token, node.Token.Literal)
+		}
+		return value
 	case *ast.ObjectCallExpression:
-		res := evalObjectCallExpression(node, env, node.Token)
-		return res
+		return evalChainExpression(node, env)
 	}
 	return nil
 }
@@ -147,6 +164,14 @@ func evalExpressions(
 ) []object.Object {
 	var result []object.Object
 	for _, e := range exps {
+		if spread, ok := e.(*ast.SpreadExpression); ok {
+			elements := evalSpreadExpression(spread, env)
+			if len(elements) == 1 && isError(elements[0]) {
+				return elements
+			}
+			result = append(result, elements...)
+			continue
+		}
 		evaluated := Eval(e, env)
 		if isError(evaluated) {
 			return []object.Object{evaluated}
@@ -161,6 +186,13 @@ func evalIdentifier(
 	env *object.Environment,
 	token token.Token,
 ) object.Object {
+	if node.Resolved {
+		if val, ok := env.GetAt(node.Depth, node.Slot); ok {
+			return val
+		}
+		return newError("identifier not found: "+node.Value, token)
+	}
+
 	if val, ok := env.Get(node.Value); ok {
 		return val
 	}
@@ -172,6 +204,43 @@ func evalIdentifier(
 	return newError("identifier not found: "+node.Value, token)
 }
 
+func lookupBinding(ident *ast.Identifier, env *object.Environment) (object.Object, bool) {
+	if ident.Resolved {
+		return env.GetAt(ident.Depth, ident.Slot)
+	}
+	return env.Get(ident.Value)
+}
+
+func declareBinding(ident *ast.Identifier, val object.Object, env *object.Environment, constant bool) {
+	switch {
+	case ident.Resolved && constant:
+		env.SetConstAt(ident.Slot, val)
+	case ident.Resolved:
+		env.SetAt(ident.Slot, val)
+	case constant:
+		env.SetConst(ident.Value, val)
+	default:
+		env.Set(ident.Value, val)
+	}
+}
+
+func assignBinding(ident *ast.Identifier, val object.Object, env *object.Environment) bool {
+	var ok bool
+	if ident.Resolved {
+		_, ok = env.AssignAt(ident.Depth, ident.Slot, val)
+	} else {
+		_, ok = env.Assign(ident.Value, val)
+	}
+	return ok
+}
+
+func isConstBinding(ident *ast.Identifier, env *object.Environment) bool {
+	if ident.Resolved {
+		return env.IsConstAt(ident.Depth, ident.Slot)
+	}
+	return env.IsConst(ident.Value)
+}
+
 func evalProgram(program *ast.Program, env *object.Environment) object.Object {
 	var result object.Object
 	for _, statement := range program.Statements {
@@ -213,6 +282,8 @@ func evalPrefixExpression(operator string, right object.Object, token token.Toke
 		return evalBangOperatorExpression(right)
 	case "-":
 		return evalMinusPrefixOperatorExpression(right, token)
+	case "~":
+		return evalTildePrefixOperatorExpression(right, token)
 	default:
 		return newError("unknown operator: %s %s", token, operator, right.Type())
 	}
@@ -226,29 +297,33 @@ func evalPostfixExpression(
 ) object.Object {
 	switch operator {
 	case "++":
-		val, ok := env.Get(node.Token.Literal)
+		val, ok := lookupBinding(node.Name, env)
 		if !ok {
 			return newError("%s is unknown", token, node.Token.Literal)
 		}
+		if isConstBinding(node.Name, env) {
+			return newError("Cannot assign to constant `%s`", token, node.Token.Literal)
+		}
 
 		switch arg := val.(type) {
-		case *object.Integer:
-			v := arg.Value
-			env.Set(node.Token.Literal, &object.Integer{Value: v + 1})
+		case *object.Integer, *object.BigInteger:
+			assignBinding(node.Name, evalIntegerInfixExpression("+", arg, &object.Integer{Value: 1}, token), env)
 			return arg
 		default:
 			return newError("%s is not an int", token, node.Token.Literal)
 		}
 	case "--":
-		val, ok := env.Get(node.Token.Literal)
+		val, ok := lookupBinding(node.Name, env)
 		if !ok {
 			return newError("%s is unknown", token, node.Token.Literal)
 		}
+		if isConstBinding(node.Name, env) {
+			return newError("Cannot assign to constant `%s`", token, node.Token.Literal)
+		}
 
 		switch arg := val.(type) {
-		case *object.Integer:
-			v := arg.Value
-			env.Set(node.Token.Literal, &object.Integer{Value: v - 1})
+		case *object.Integer, *object.BigInteger:
+			assignBinding(node.Name, evalIntegerInfixExpression("-", arg, &object.Integer{Value: 1}, token), env)
 			return arg
 		default:
 			return newError("%s is not an int", token, node.Token.Literal)
@@ -261,6 +336,7 @@ func evalPostfixExpression(
 func evalInfixExpression(
 	operator string,
 	left, right object.Object,
+	env *object.Environment,
 	token token.Token,
 ) object.Object {
 	switch {
@@ -272,8 +348,16 @@ func evalInfixExpression(
 		return evalFloatIntegerInfixExpression(operator, left, right, token)
 	case left.Type() == object.INTEGER_OBJ && right.Type() == object.FLOAT_OBJ:
 		return evalIntegerFloatInfixExpression(operator, left, right, token)
+	case left.Type() == object.DECIMAL_OBJ && right.Type() == object.DECIMAL_OBJ,
+		left.Type() == object.DECIMAL_OBJ && right.Type() == object.INTEGER_OBJ,
+		left.Type() == object.INTEGER_OBJ && right.Type() == object.DECIMAL_OBJ:
+		return evalDecimalInfixExpression(operator, left, right, env.DecimalContext(), token)
 	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
 		return evalStringInfixExpression(operator, left, right, token)
+	case left.Type() == object.ENUM_VALUE_OBJ && right.Type() == object.ENUM_VALUE_OBJ && operator == "==":
+		return nativeBoolToBooleanObject(enumValuesEqual(left.(*object.EnumValue), right.(*object.EnumValue), token))
+	case left.Type() == object.ENUM_VALUE_OBJ && right.Type() == object.ENUM_VALUE_OBJ && operator == "!=":
+		return nativeBoolToBooleanObject(!enumValuesEqual(left.(*object.EnumValue), right.(*object.EnumValue), token))
 	case operator == "==":
 		return nativeBoolToBooleanObject(left == right)
 	case operator == "!=":
@@ -307,7 +391,14 @@ func evalBangOperatorExpression(right object.Object) object.Object {
 func evalMinusPrefixOperatorExpression(right object.Object, token token.Token) object.Object {
 	switch obj := right.(type) {
 	case *object.Integer:
+		if obj.Value == math.MinInt64 {
+			return bigIntegerToObject(new(big.Int).Neg(big.NewInt(obj.Value)))
+		}
 		return &object.Integer{Value: -obj.Value}
+	case *object.BigInteger:
+		return bigIntegerToObject(new(big.Int).Neg(obj.Value))
+	case *object.Decimal:
+		return obj.Neg()
 	case *object.Float:
 		return &object.Float{Value: -obj.Value}
 	default:
@@ -315,6 +406,17 @@ func evalMinusPrefixOperatorExpression(right object.Object, token token.Token) o
 	}
 }
 
+func evalTildePrefixOperatorExpression(right object.Object, token token.Token) object.Object {
+	switch obj := right.(type) {
+	case *object.Integer:
+		return &object.Integer{Value: ^obj.Value}
+	case *object.BigInteger:
+		return bigIntegerToObject(new(big.Int).Not(obj.Value))
+	default:
+		return newError("unknown operator: ~%s", token, right.Type())
+	}
+}
+
 func coerceObjectToNativeBool(o object.Object) bool {
 	if rv, ok := o.(*object.ReturnValue); ok {
 		o = rv.Value
@@ -332,7 +434,9 @@ func coerceObjectToNativeBool(o object.Object) bool {
 	case *object.Array:
 		return len(obj.Elements) > 0
 	case *object.Hash:
-		return len(obj.Pairs) > 0
+		return obj.Len() > 0
+	case *object.Range:
+		return obj.Len() > 0
 	default:
 		return true
 	}
@@ -343,16 +447,40 @@ func evalIntegerInfixExpression(
 	left, right object.Object,
 	token token.Token,
 ) object.Object {
-	leftVal := left.(*object.Integer).Value
-	rightVal := right.(*object.Integer).Value
+	leftInt, leftOk := left.(*object.Integer)
+	rightInt, rightOk := right.(*object.Integer)
+	if (operator == ".." || operator == "..=") && (!leftOk || !rightOk) {
+		return newError("range bounds out of range: %s %s %s", token, left.Inspect(), operator, right.Inspect())
+	}
+	if !leftOk || !rightOk {
+		return evalBigIntegerInfixExpression(operator, toBigInt(left), toBigInt(right), token)
+	}
+
+	leftVal := leftInt.Value
+	rightVal := rightInt.Value
 	switch operator {
 	case "+":
-		return &object.Integer{Value: leftVal + rightVal}
+		if sum, ok := addInt64(leftVal, rightVal); ok {
+			return &object.Integer{Value: sum}
+		}
+		return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), token)
 	case "-":
-		return &object.Integer{Value: leftVal - rightVal}
+		if diff, ok := subInt64(leftVal, rightVal); ok {
+			return &object.Integer{Value: diff}
+		}
+		return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), token)
 	case "*":
-		return &object.Integer{Value: leftVal * rightVal}
+		if product, ok := mulInt64(leftVal, rightVal); ok {
+			return &object.Integer{Value: product}
+		}
+		return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), token)
 	case "/":
+		if rightVal == 0 {
+			return newError("division by zero: %d / 0", token, leftVal)
+		}
+		if leftVal == math.MinInt64 && rightVal == -1 {
+			return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), token)
+		}
 		return &object.Integer{Value: leftVal / rightVal}
 	case "<":
 		return nativeBoolToBooleanObject(leftVal < rightVal)
@@ -367,9 +495,41 @@ func evalIntegerInfixExpression(
 	case ">=":
 		return nativeBoolToBooleanObject(leftVal >= rightVal)
 	case "%":
+		if rightVal == 0 {
+			return newError("modulo by zero: %d %% 0", token, leftVal)
+		}
 		return &object.Integer{Value: leftVal % rightVal}
 	case "^":
-		return &object.Integer{Value: powInt(leftVal, rightVal)}
+		if rightVal < 0 {
+			return negativePowInt(leftVal, rightVal, token)
+		}
+		if power, ok := powInt64(leftVal, rightVal); ok {
+			return &object.Integer{Value: power}
+		}
+		return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), token)
+	case "&":
+		return &object.Integer{Value: leftVal & rightVal}
+	case "|":
+		return &object.Integer{Value: leftVal | rightVal}
+	case "~":
+		return &object.Integer{Value: leftVal ^ rightVal}
+	case "<<":
+		if shifted, ok := shiftLeftInt64(leftVal, rightVal); ok {
+			return &object.Integer{Value: shifted}
+		}
+		return evalBigIntegerInfixExpression(operator, big.NewInt(leftVal), big.NewInt(rightVal), token)
+	case ">>":
+		if rightVal < 0 {
+			return newError("negative shift count: %d", token, rightVal)
+		}
+		if rightVal > 63 {
+			rightVal = 63
+		}
+		return &object.Integer{Value: leftVal >> uint(rightVal)}
+	case "..":
+		return &object.Range{Start: leftVal, Stop: rightVal, Step: 1}
+	case "..=":
+		return &object.Range{Start: leftVal, Stop: rightVal, Step: 1, Inclusive: true}
 	default:
 		return newError("unknown operator: %s %s %s", token,
 			left.Type(), operator, right.Type())
@@ -413,7 +573,7 @@ func evalFloatInfixExpression(operator string, left, right object.Object, token
 
 func evalFloatIntegerInfixExpression(operator string, left, right object.Object, token token.Token) object.Object {
 	leftVal := left.(*object.Float).Value
-	rightVal := float64(right.(*object.Integer).Value)
+	rightVal := integerToFloat(right)
 	switch operator {
 	case "+":
 		return &object.Float{Value: leftVal + rightVal}
@@ -447,7 +607,7 @@ func evalFloatIntegerInfixExpression(operator string, left, right object.Object,
 }
 
 func evalIntegerFloatInfixExpression(operator string, left, right object.Object, token token.Token) object.Object {
-	leftVal := float64(left.(*object.Integer).Value)
+	leftVal := integerToFloat(left)
 	rightVal := right.(*object.Float).Value
 	switch operator {
 	case "+":
@@ -481,6 +641,65 @@ func evalIntegerFloatInfixExpression(operator string, left, right object.Object,
 	}
 }
 
+func evalDecimalInfixExpression(
+	operator string,
+	left, right object.Object,
+	context object.DecimalContext,
+	token token.Token,
+) object.Object {
+	leftVal := toDecimal(left)
+	rightVal := toDecimal(right)
+	switch operator {
+	case "+":
+		return leftVal.Add(rightVal)
+	case "-":
+		return leftVal.Sub(rightVal)
+	case "*":
+		return leftVal.Mul(rightVal)
+	case "/":
+		if rightVal.Sign() == 0 {
+			return newError("division by zero: %s / 0", token, leftVal.Inspect())
+		}
+		return leftVal.Div(rightVal, context)
+	case "%":
+		if rightVal.Sign() == 0 {
+			return newError("modulo by zero: %s %% 0", token, leftVal.Inspect())
+		}
+		return leftVal.Rem(rightVal)
+	case "^":
+		exponent, ok := right.(*object.Integer)
+		if !ok || exponent.Value < 0 {
+			return newError("exponent of a DECIMAL must be a non-negative INTEGER, got %s", token, right.Inspect())
+		}
+		if decimalPowTooLarge(leftVal, exponent.Value) {
+			return newError("exponent too large: %d", token, exponent.Value)
+		}
+		return leftVal.Pow(exponent.Value, context)
+	case "<":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) > 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) != 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) >= 0)
+	default:
+		return newError("unknown operator: %s %s %s", token,
+			left.Type(), operator, right.Type())
+	}
+}
+
+func toDecimal(obj object.Object) *object.Decimal {
+	if decimal, ok := obj.(*object.Decimal); ok {
+		return decimal
+	}
+	return object.NewDecimalFromInt(toBigInt(obj))
+}
+
 func evalStringInfixExpression(
 	operator string,
 	left, right object.Object,
@@ -510,7 +729,7 @@ func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Obje
 	}
 
 	if isTruthy(condition) {
-		return Eval(ie.Consequence, env)
+		return evalBlockStatement(ie.Consequence, object.NewEnclosedEnvironment(env))
 	} else {
 		if ie.Elif != nil {
 			for _, elifExpr := range ie.Elif {
@@ -519,12 +738,12 @@ func evalIfExpression(ie *ast.IfExpression, env *object.Environment) object.Obje
 					return elifCondition
 				}
 				if isTruthy(elifCondition) {
-					return Eval(elifExpr.Consequence, env)
+					return evalBlockStatement(elifExpr.Consequence, object.NewEnclosedEnvironment(env))
 				}
 			}
 		}
 		if ie.Else != nil {
-			return Eval(ie.Else, env)
+			return evalBlockStatement(ie.Else, object.NewEnclosedEnvironment(env))
 		}
 	}
 
@@ -554,10 +773,6 @@ func PrintParserErrors(out io.Writer, errors []string) {
 	}
 }
 
-func powInt(x, y int64) int64 {
-	return int64(math.Pow(float64(x), float64(y)))
-}
-
 func isError(obj object.Object) bool {
 	if obj != nil {
 		return obj.Type() == object.ERROR_OBJ
@@ -565,13 +780,33 @@ func isError(obj object.Object) bool {
 	return false
 }
 
-func applyFunction(fn object.Object, args []object.Object, token token.Token) object.Object {
+func applyFunction(
+	fn object.Object,
+	args []object.Object,
+	named map[string]object.Object,
+	token token.Token,
+) object.Object {
 	switch fn := fn.(type) {
 	case *object.Function:
-		extendedEnv := extendFunctionEnv(fn, args)
+		extendedEnv, err := extendFunctionEnv(fn, args, named, token)
+		if err != nil {
+			return err
+		}
+		if fn.IsGenerator {
+			return newGenerator(fn, extendedEnv)
+		}
 		evaluated := Eval(fn.Body, extendedEnv)
 		return unwrapReturnValue(evaluated)
 	case *object.Builtin:
+		if fn.EnvFn != nil {
+			return newError("builtin function can only be called directly", token)
+		}
+		if fn.NamedFn != nil {
+			return fn.NamedFn(token, named, args...)
+		}
+		if len(named) > 0 {
+			return newError("builtin function does not accept named arguments, got `%s`", token, sortedNames(named)[0])
+		}
 		return fn.Fn(token, args...)
 	default:
 		return newError("not a function: %s", token, fn.Type())
@@ -581,12 +816,174 @@ func applyFunction(fn object.Object, args []object.Object, token token.Token) ob
 func extendFunctionEnv(
 	fn *object.Function,
 	args []object.Object,
-) *object.Environment {
+	named map[string]object.Object,
+	tok token.Token,
+) (*object.Environment, *object.Error) {
+	if err := checkArity(fn, len(args), len(named) > 0, tok); err != nil {
+		return nil, err
+	}
+	if err := checkNamedArguments(fn, named, tok); err != nil {
+		return nil, err
+	}
+
 	env := object.NewEnclosedEnvironment(fn.Env)
 	for paramIdx, param := range fn.Parameters {
-		env.Set(param.Value, args[paramIdx])
+		value, byName := named[parameterName(param)]
+		switch {
+		case param.Rest:
+			rest := []object.Object{}
+			if paramIdx < len(args) {
+				rest = append(rest, args[paramIdx:]...)
+			}
+			value = &object.Array{Elements: rest}
+		case paramIdx < len(args):
+			if byName {
+				return nil, newError("got multiple values for argument `%s`", tok, parameterName(param))
+			}
+			value = args[paramIdx]
+		case byName:
+		case param.Default != nil:
+			value = Eval(param.Default, env)
+			if err, ok := value.(*object.Error); ok {
+				return nil, err
+			}
+		default:
+			return nil, newError("missing argument for parameter `%s`", tok, param.String())
+		}
+
+		if ident, ok := param.Target.(*ast.Identifier); ok {
+			declareBinding(ident, value, env, false)
+			continue
+		}
+		if err := bindPattern(param.Target, value, env, token.FUNCTION, tok); err != nil {
+			return nil, err
+		}
+	}
+	return env, nil
+}
+
+func parameterName(param *ast.Parameter) string {
+	if ident, ok := param.Target.(*ast.Identifier); ok && !param.Rest {
+		return ident.Value
+	}
+	return ""
+}
+
+func checkNamedArguments(fn *object.Function, named map[string]object.Object, tok token.Token) *object.Error {
+	for _, name := range sortedNames(named) {
+		known := false
+		for _, param := range fn.Parameters {
+			if parameterName(param) == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return newError("unknown named argument `%s`", tok, name)
+		}
+	}
+	return nil
+}
+
+func sortedNames(named map[string]object.Object) []string {
+	names := make([]string, 0, len(named))
+	for name := range named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func evalArguments(
+	exps []ast.Expression,
+	env *object.Environment,
+) ([]object.Object, map[string]object.Object, object.Object) {
+	split := len(exps)
+	for i, e := range exps {
+		if _, ok := e.(*ast.NamedArgument); ok {
+			split = i
+			break
+		}
+	}
+
+	args := evalExpressions(exps[:split], env)
+	if len(args) == 1 && isError(args[0]) {
+		return nil, nil, args[0]
+	}
+	if split == len(exps) {
+		return args, nil, nil
+	}
+
+	named := make(map[string]object.Object, len(exps)-split)
+	var previous *ast.NamedArgument
+	for _, e := range exps[split:] {
+		arg, ok := e.(*ast.NamedArgument)
+		if !ok {
+			return nil, nil, newError("positional argument follows named argument: %s", previous.Token, e.String())
+		}
+		previous = arg
+		if _, ok := named[arg.Name.Value]; ok {
+			return nil, nil, newError("duplicate named argument `%s`", arg.Token, arg.Name.Value)
+		}
+		value := Eval(arg.Value, env)
+		if isError(value) {
+			return nil, nil, value
+		}
+		named[arg.Name.Value] = value
+	}
+	return args, named, nil
+}
+
+func checkArity(fn *object.Function, got int, hasNamed bool, tok token.Token) *object.Error {
+	required, positional, variadic := 0, 0, false
+	for _, param := range fn.Parameters {
+		switch {
+		case param.Rest:
+			variadic = true
+		case param.Default == nil:
+			positional++
+			required = positional
+		default:
+			positional++
+		}
+	}
+
+	tooMany := !variadic && got > positional
+	switch {
+	case !tooMany && (got >= required || hasNamed):
+		return nil
+	case variadic:
+		return newError("wrong number of arguments. got=%d, want at least %d", tok, got, required)
+	case required == positional:
+		return newError("wrong number of arguments. got=%d, want=%d", tok, got, required)
+	default:
+		return newError("wrong number of arguments. got=%d, want=%d to %d", tok, got, required, positional)
+	}
+}
+
+func evalSpreadExpression(spread *ast.SpreadExpression, env *object.Environment) []object.Object {
+	value := Eval(spread.Value, env)
+	if isError(value) {
+		return []object.Object{value}
+	}
+	iterable, ok := value.(object.Iterable)
+	if !ok {
+		return []object.Object{newError("cannot spread %s", spread.Token, value.Type())}
+	}
+
+	iterator := iterable.Iter()
+	defer iterator.Close()
+	elements := []object.Object{}
+	for {
+		element, _, ok := iterator.Next()
+		if isError(element) {
+			return []object.Object{element}
+		}
+		if !ok {
+			return elements
+		}
+		elements = append(elements, element)
 	}
-	return env
 }
 
 func unwrapReturnValue(obj object.Object) object.Object {
@@ -599,32 +996,157 @@ func unwrapReturnValue(obj object.Object) object.Object {
 func evalIndexExpression(left, index object.Object, token token.Token) object.Object {
 	switch {
 	case left.Type() == object.ARRAY_OBJ && index.Type() == object.INTEGER_OBJ:
-		return evalArrayIndexExpression(left, index)
+		return evalArrayIndexExpression(left, index, token)
+	case left.Type() == object.STRING_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalStringIndexExpression(left, index, token)
+	case left.Type() == object.RANGE_OBJ && index.Type() == object.INTEGER_OBJ:
+		return evalRangeIndexExpression(left, index, token)
 	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpression(left, index, token)
+	case left.Type() == object.ENUM_OBJ, left.Type() == object.ENUM_VALUE_OBJ:
+		return evalEnumIndexExpression(left, index, token)
 	default:
 		return newError("index operator not supported: %s", token, left.Type())
 	}
 }
 
-func evalArrayIndexExpression(array, index object.Object) object.Object {
+func evalArrayIndexExpression(array, index object.Object, token token.Token) object.Object {
 	arrayObject := array.(*object.Array)
-	idx := index.(*object.Integer).Value
-	max := int64(len(arrayObject.Elements) - 1)
-	if idx < 0 || idx > max {
-		return NULL
+	length := int64(len(arrayObject.Elements))
+	integer, ok := index.(*object.Integer)
+	if !ok {
+		return newError("index out of range: %s (length %d)", token, index.Inspect(), length)
+	}
+	idx := integer.Value
+	if idx < 0 {
+		idx += length
+	}
+	if idx < 0 || idx >= length {
+		return newError("index out of range: %d (length %d)", token, integer.Value, length)
 	}
 	return arrayObject.Elements[idx]
 }
 
+func evalStringIndexExpression(str, index object.Object, token token.Token) object.Object {
+	runes := str.(*object.String).Runes()
+	length := int64(len(runes))
+	integer, ok := index.(*object.Integer)
+	if !ok {
+		return newError("index out of range: %s (length %d)", token, index.Inspect(), length)
+	}
+	idx := integer.Value
+	if idx < 0 {
+		idx += length
+	}
+	if idx < 0 || idx >= length {
+		return newError("index out of range: %d (length %d)", token, integer.Value, length)
+	}
+	return &object.String{Value: string(runes[idx])}
+}
+
+func evalRangeIndexExpression(rng, index object.Object, token token.Token) object.Object {
+	rangeObject := rng.(*object.Range)
+	length := rangeObject.Len()
+	integer, ok := index.(*object.Integer)
+	if !ok {
+		return newError("index out of range: %s (length %d)", token, index.Inspect(), length)
+	}
+	idx := integer.Value
+	if idx < 0 {
+		idx += length
+	}
+	if idx < 0 || idx >= length {
+		return newError("index out of range: %d (length %d)", token, integer.Value, length)
+	}
+	return &object.Integer{Value: rangeObject.At(idx)}
+}
+
+func evalSliceExpression(node *ast.SliceExpression, left object.Object, env *object.Environment) object.Object {
+	bounds := []object.Object{nil, nil, nil}
+	for i, exp := range []ast.Expression{node.Start, node.Stop, node.Step} {
+		if exp == nil {
+			continue
+		}
+		bound := Eval(exp, env)
+		if isError(bound) {
+			return bound
+		}
+		if bound.Type() != object.INTEGER_OBJ {
+			return newError("slice indices must be INTEGER, got %s", node.Token, bound.Type())
+		}
+		if _, ok := bound.(*object.Integer); !ok {
+			return newError("slice index out of range: %s", node.Token, bound.Inspect())
+		}
+		bounds[i] = bound
+	}
+
+	step := int64(1)
+	if bounds[2] != nil {
+		step = bounds[2].(*object.Integer).Value
+	}
+	if step == 0 {
+		return newError("slice step cannot be zero", node.Token)
+	}
+
+	switch left := left.(type) {
+	case *object.Array:
+		indices := sliceIndices(int64(len(left.Elements)), bounds[0], bounds[1], step)
+		elements := make([]object.Object, len(indices))
+		for i, idx := range indices {
+			elements[i] = left.Elements[idx]
+		}
+		return &object.Array{Elements: elements}
+	case *object.String:
+		runes := left.Runes()
+		indices := sliceIndices(int64(len(runes)), bounds[0], bounds[1], step)
+		out := make([]rune, len(indices))
+		for i, idx := range indices {
+			out[i] = runes[idx]
+		}
+		return &object.String{Value: string(out)}
+	default:
+		return newError("slice operator not supported: %s", node.Token, left.Type())
+	}
+}
+
+func sliceIndices(length int64, start, stop object.Object, step int64) []int64 {
+	clamp := func(bound object.Object, fallback int64) int64 {
+		if bound == nil {
+			return fallback
+		}
+		idx := bound.(*object.Integer).Value
+		if idx < 0 {
+			idx += length
+		}
+		if step > 0 {
+			return max(0, min(idx, length))
+		}
+		return max(-1, min(idx, length-1))
+	}
+
+	indices := []int64{}
+	if step > 0 {
+		end := clamp(stop, length)
+		for i := clamp(start, 0); i < end; i += step {
+			indices = append(indices, i)
+		}
+	} else {
+		end := clamp(stop, -1)
+		for i := clamp(start, length-1); i > end; i += step {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
 func evalHashLiteral(
 	node *ast.HashLiteral,
 	env *object.Environment,
 	token token.Token,
 ) object.Object {
-	pairs := make(map[object.HashKey]object.HashPair)
-	for keyNode, valueNode := range node.Pairs {
-		key := Eval(keyNode, env)
+	hash := object.NewHash()
+	for _, pairNode := range node.Pairs {
+		key := Eval(pairNode.Key, env)
 		if isError(key) {
 			return key
 		}
@@ -632,14 +1154,13 @@ func evalHashLiteral(
 		if !ok {
 			return newError("unusable as hash key: %s", token, key.Type())
 		}
-		value := Eval(valueNode, env)
+		value := Eval(pairNode.Value, env)
 		if isError(value) {
 			return value
 		}
-		hashed := hashKey.HashKey()
-		pairs[hashed] = object.HashPair{Key: key, Value: value}
+		hash.Set(hashKey.HashKey(), object.HashPair{Key: key, Value: value})
 	}
-	return &object.Hash{Pairs: pairs}
+	return hash
 }
 
 func evalHashIndexExpression(hash, index object.Object, token token.Token) object.Object {
@@ -648,7 +1169,7 @@ func evalHashIndexExpression(hash, index object.Object, token token.Token) objec
 	if !ok {
 		return newError("unusable as hash key: %s", token, index.Type())
 	}
-	pair, ok := hashObject.Pairs[key.HashKey()]
+	pair, ok := hashObject.Get(key.HashKey())
 	if !ok {
 		return NULL
 	}
@@ -663,8 +1184,8 @@ func evalForLoopExpression(fle *ast.ForLoopExpression, env *object.Environment)
 			return condition
 		}
 		if !isTruthy(condition) {
-			rt := Eval(fle.Consequence, env)
-			if !isError(rt) && (rt.Type() == object.RETURN_VALUE_OBJ || rt.Type() == object.ERROR_OBJ) {
+			rt := evalBlockStatement(fle.Consequence, object.NewEnclosedEnvironment(env))
+			if rt != nil && (rt.Type() == object.RETURN_VALUE_OBJ || rt.Type() == object.ERROR_OBJ) {
 				return rt
 			}
 		} else {
@@ -677,6 +1198,13 @@ func evalForLoopExpression(fle *ast.ForLoopExpression, env *object.Environment)
 func evalImportStatement(is *ast.ImportStatement, env *object.Environment) {
 	filePath := is.Path.Value
 	file.SetFileName(filePath)
+	defer env.SetDecimalContext(env.DecimalContext())
+	if is.Program != nil {
+		Eval(is.Program, env)
+		file.SetFileName(file.GetMainFileName())
+		return
+	}
+
 	contents, err := os.ReadFile(filePath)
 
 	if err != nil {
@@ -703,41 +1231,88 @@ func evalAssignStatement(vs *ast.AssignStatement, env *object.Environment, token
 		return val
 	}
 
-	if vs.Token.Type == token.VAR {
-		if _, ok := env.Get(vs.Name.Value); ok {
-			return newError("Variable `%s` already defined", token_, vs.Name.Value)
+	if vs.Pattern != nil {
+		if err := bindPattern(vs.Pattern, val, env, vs.Token.Type, vs.Token); err != nil {
+			return err
+		}
+		return NULL
+	}
+
+	if vs.Token.Type == token.MUTATE {
+		if isConstBinding(vs.Name, env) {
+			return newError("Cannot assign to constant `%s`", token_, vs.Name.Value)
 		}
-	} else if vs.Token.Type == token.MUTATE {
-		if _, ok := env.Get(vs.Name.Value); !ok {
+		if !assignBinding(vs.Name, val, env) {
 			return newError("Variable `%s` not defined", token_, vs.Name.Value)
 		}
+		return NULL
+	}
+
+	if !vs.Name.Resolved {
+		if _, ok := env.Get(vs.Name.Value); ok {
+			return newError("Variable `%s` already defined", token_, vs.Name.Value)
+		}
 	}
 
-	env.Set(vs.Name.Value, val)
+	declareBinding(vs.Name, val, env, vs.Token.Type == token.CONST)
 	return NULL
 }
 
 func evalSwitchStatement(se *ast.SwitchExpression, env *object.Environment) object.Object {
 	obj := Eval(se.Value, env)
+	if isError(obj) {
+		return obj
+	}
 
 	for _, opt := range se.Choices {
-		if opt.Default.Token.Type == token.TRUE {
+		if opt.Default != nil {
 			continue
 		}
 
+		if opt.Pattern != nil {
+			receiver := Eval(opt.Pattern.Enum, env)
+			if isError(receiver) {
+				return receiver
+			}
+			if receiver.Type() == object.ENUM_OBJ {
+				bindings, matched, err := matchPattern(opt.Pattern, obj, nil, env, opt.Token)
+				if err != nil {
+					return err
+				}
+				if !matched {
+					continue
+				}
+				caseEnv := object.NewEnclosedEnvironment(env)
+				if err := declarePatternBindings(bindings, opt.Pattern, caseEnv); err != nil {
+					return err
+				}
+				return evalBlockStatement(opt.Block, caseEnv)
+			}
+		}
+
 		val := Eval(opt.Expr, env)
+		if isError(val) {
+			return val
+		}
+
+		if enumValue, ok := obj.(*object.EnumValue); ok {
+			if other, ok := val.(*object.EnumValue); ok && enumValuesEqual(enumValue, other, se.Token) {
+				return evalBlockStatement(opt.Block, object.NewEnclosedEnvironment(env))
+			}
+			continue
+		}
 
 		if obj.Type() == val.Type() &&
 			(obj.Inspect() == val.Inspect()) {
 
-			out := evalBlockStatement(opt.Block, env)
+			out := evalBlockStatement(opt.Block, object.NewEnclosedEnvironment(env))
 			return out
 		}
 	}
 
 	for _, opt := range se.Choices {
-		if opt.Default.Token.Type == token.TRUE {
-			out := evalBlockStatement(opt.Block, env)
+		if opt.Default != nil {
+			out := evalBlockStatement(opt.Block, object.NewEnclosedEnvironment(env))
 			return out
 		}
 	}
@@ -748,37 +1323,124 @@ func evalSwitchStatement(se *ast.SwitchExpression, env *object.Environment) obje
 func evalForeachExpression(fle *ast.ForeachStatement, env *object.Environment, token token.Token) object.Object {
 	val := Eval(fle.Value, env)
 
-	helper, ok := val.(object.Iterable)
+	iterable, ok := val.(object.Iterable)
 	if !ok {
 		return newError("%s object doesn't implement the Iterable interface", token, val.Type())
 	}
 
-	child := object.NewEnclosedEnvironment(env)
-	helper.Reset()
+	helper := iterable.Iter()
+	defer helper.Close()
 	ret, idx, ok := helper.Next()
 
 	for ok {
-		child.Set(fle.Identifier.Value, ret)
+		child := object.NewEnclosedEnvironment(env)
+		if fle.Pattern != nil {
+			if err := bindPattern(fle.Pattern, ret, child, fle.Token.Type, fle.Token); err != nil {
+				return err
+			}
+		} else {
+			declareBinding(fle.Identifier, ret, child, false)
+		}
 		if fle.Index != nil && fle.Index.Value != "" {
-			child.Set(fle.Index.Value, idx)
+			declareBinding(fle.Index, idx, child, false)
 		}
 
-		rt := Eval(fle.Body, child)
-		if !isError(rt) && (rt.Type() == object.RETURN_VALUE_OBJ || rt.Type() == object.ERROR_OBJ) {
+		rt := evalBlockStatement(fle.Body, child)
+		if rt != nil && (rt.Type() == object.RETURN_VALUE_OBJ || rt.Type() == object.ERROR_OBJ) {
 			return rt
 		}
 
 		ret, idx, ok = helper.Next()
 	}
+	if err, ok := ret.(*object.Error); ok {
+		if err.FileName == "" {
+			err.FileName = file.GetFileName()
+			err.Token = token
+		}
+		return err
+	}
 
 	return &object.Null{}
 }
 
-func evalObjectCallExpression(call *ast.ObjectCallExpression, env *object.Environment, token token.Token) object.Object {
-	obj := Eval(call.Object, env)
+// evalChainExpression evaluates a member, index, slice or call expression.
+// Once an optional link such as a?.b meets null, every link after it in the
+// same chain is skipped and the whole chain evaluates to null.
+func evalChainExpression(node ast.Expression, env *object.Environment) object.Object {
+	result, aborted := evalChainLink(node, env)
+	if aborted {
+		return NULL
+	}
+	return result
+}
+
+func evalChainLink(node ast.Expression, env *object.Environment) (object.Object, bool) {
+	switch node := node.(type) {
+	case *ast.IndexExpression:
+		left, aborted := evalChainReceiver(node.Left, node.Optional, env)
+		if aborted || isError(left) {
+			return left, aborted
+		}
+		index := Eval(node.Index, env)
+		if isError(index) {
+			return index, false
+		}
+		return evalIndexExpression(left, index, node.Token), false
+	case *ast.SliceExpression:
+		left, aborted := evalChainReceiver(node.Left, node.Optional, env)
+		if aborted || isError(left) {
+			return left, aborted
+		}
+		return evalSliceExpression(node, left, env), false
+	case *ast.ObjectCallExpression:
+		obj, aborted := evalChainReceiver(node.Object, node.Optional, env)
+		if aborted || isError(obj) {
+			return obj, aborted
+		}
+		return evalObjectCallExpression(node, obj, env, node.Token), false
+	case *ast.CallExpression:
+		if node.Function.TokenLiteral() == "quote" {
+			return Eval(node, env), false
+		}
+		function, aborted := evalChainReceiver(node.Function, false, env)
+		if aborted || isError(function) {
+			return function, aborted
+		}
+		args, named, err := evalArguments(node.Arguments, env)
+		if err != nil {
+			return err, false
+		}
+		if builtin, ok := function.(*object.Builtin); ok && builtin.EnvFn != nil && len(named) == 0 {
+			return builtin.EnvFn(env, node.Token, args...), false
+		}
+		return applyFunction(function, args, named, node.Token), false
+	default:
+		return Eval(node, env), false
+	}
+}
+
+func evalChainReceiver(node ast.Expression, optional bool, env *object.Environment) (object.Object, bool) {
+	receiver, aborted := evalChainLink(node, env)
+	if aborted || isError(receiver) {
+		return receiver, aborted
+	}
+	return receiver, optional && receiver.Type() == object.NULL_OBJ
+}
+
+func evalObjectCallExpression(call *ast.ObjectCallExpression, obj object.Object, env *object.Environment, token token.Token) object.Object {
 	if method, ok := call.Call.(*ast.CallExpression); ok {
 		args := evalExpressions(call.Call.(*ast.CallExpression).Arguments, env)
-		ret := obj.InvokeMethod(method.Function.String(), args...)
+		if len(args) == 1 && isError(args[0]) {
+			return args[0]
+		}
+		apply := func(fn object.Object, args ...object.Object) object.Object {
+			return applyFunction(fn, args, nil, token)
+		}
+		ret := obj.InvokeMethod(method.Function.String(), apply, args...)
+		if err, ok := ret.(*object.Error); ok && err.FileName == "" {
+			err.FileName = file.GetFileName()
+			err.Token = token
+		}
 		if ret != nil {
 			return ret
 		}
@@ -0,0 +1,170 @@
+package evaluator
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/file"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/lexer"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/parser"
+)
+
+type outputTest struct {
+	input    string
+	expected string
+}
+
+func parseProgram(t *testing.T, input string) *ast.Program {
+	t.Helper()
+	file.SetFileName("test.jak")
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) != 0 {
+		t.Fatalf("%q: unexpected parser errors: %v", input, errs)
+	}
+	return program
+}
+
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+	defer func() {
+		os.Stdout, os.Stderr = stdout, stderr
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+func run(t *testing.T, input string) string {
+	t.Helper()
+	program := parseProgram(t, input)
+	return captureOutput(t, func() { Eval(program, object.NewEnvironment()) })
+}
+
+func testOutput(t *testing.T, tests []outputTest) {
+	t.Helper()
+	for _, tt := range tests {
+		if got := run(t, tt.input); got != tt.expected {
+			t.Errorf("%q: got output %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func testErrors(t *testing.T, tests []outputTest) {
+	t.Helper()
+	for _, tt := range tests {
+		if got := run(t, tt.input); !strings.Contains(got, "Error: `"+tt.expected+"`") {
+			t.Errorf("%q: got output %q, want error %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestNumericLiterals(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println(0xFF + 1_000)", "1255\n"},
+		{"println(0o17 * 0b11)", "45\n"},
+		{"println(1.5e3)", "1500\n"},
+		{"println(2.5e-1)", "0.25\n"},
+	})
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println(0.1d + 0.2d)", "0.3\n"},
+		{"println(12.50d * 3)", "37.50\n"},
+		{"println(10d - 0.01d)", "9.99\n"},
+		{"println(1d / 3d)", "0.3333333333333333\n"},
+		{"println(1.10d / 2)", "0.55\n"},
+		{"println(-1.5d)", "-1.5\n"},
+		{"println(1.5d ^ 2)", "2.25\n"},
+		{"println(1.50d ^ 2)", "2.2500\n"},
+		{"println(0.1d ^ 20)", "0.0\n"},
+		{"println(1.1d ^ 20)", "6.7274999493256001\n"},
+		{"decimalcontext(3); println(1.05d ^ 3)", "1.158\n"},
+		{`decimalcontext(2, "up"); println(0.5d ^ 3)`, "0.13\n"},
+		{"println(1d ^ 100000000000)", "1\n"},
+		{"println(1d == 1)", "true\n"},
+		{"println(2.50d > 2)", "true\n"},
+		{`println(decimal("12.345", 2))`, "12.34\n"},
+		{`println(decimal("12.345", 2, "half_up"))`, "12.35\n"},
+		{"println(decimal(7))", "7\n"},
+		{"println(typeof(12.50d))", "DECIMAL\n"},
+	})
+	testErrors(t, []outputTest{
+		{"1d / 0", "division by zero: 1 / 0"},
+		{"1d + 1.5", "type mismatch: DECIMAL + FLOAT"},
+		{"1.5d ^ 100000000", "exponent too large: 100000000"},
+		{"0.1d ^ 10000000", "exponent too large: 10000000"},
+		{"1.5d ^ -1", "exponent of a DECIMAL must be a non-negative INTEGER, got -1"},
+		{`decimal("abc")`, "could not convert string to decimal"},
+		{`decimalcontext(2, "sideways")`, "unknown rounding mode given to `decimalcontext`: sideways"},
+	})
+}
+
+func TestDecimalContextIsScoped(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`decimalcontext(2, "up"); println(1d / 3d)`, "0.34\n"},
+		{`decimalcontext(2); decimalcontext(3); println(2d / 3d)`, "0.667\n"},
+		{`var f = func() { decimalcontext(2, "down"); 2d / 3d }; println(f()); println(2d / 3d)`,
+			"0.66\n0.6666666666666667\n"},
+		{`if (true) { decimalcontext(1) }; println(1d / 4d)`, "0.25\n"},
+		{`decimalcontext(2); var f = func() { 1d / 3d }; println(f())`, "0.33\n"},
+	})
+	testErrors(t, []outputTest{
+		{"[1].map(decimalcontext)", "builtin function can only be called directly"},
+	})
+}
+
+func TestDecimalContextDoesNotLeakFromImports(t *testing.T) {
+	module := filepath.Join(t.TempDir(), "module.jak")
+	if err := os.WriteFile(module, []byte("decimalcontext(2)\nvar third = 1d / 3d\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	testOutput(t, []outputTest{
+		{fmt.Sprintf("use %q\nprintln(third)\nprintln(1d / 3d)", module), "0.33\n0.3333333333333333\n"},
+	})
+}
+
+func TestDecimalHashKeys(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`println({1: "a"}[1d])`, "a\n"},
+		{`println({1: "a"}[1.000d])`, "a\n"},
+		{`println({2.5d: "b"}[2.50d])`, "b\n"},
+		{`println(len({1: "a", 1.0d: "b"}))`, "1\n"},
+	})
+}
+
+func TestNumberMethodCalls(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println(5.abs())", "5\n"},
+		{"println((-5).abs())", "5\n"},
+		{"println(1.2345.round(2))", "1.23\n"},
+		{"println(9007199254740993.toFixed(2))", "9007199254740993.00\n"},
+		{"println(255.toString(16))", "ff\n"},
+		{"println(true.toInt() + 1)", "2\n"},
+		{"var x = 7; println(x.clamp(1, 5))", "5\n"},
+	})
+	testErrors(t, []outputTest{
+		{"1234.toFixed(-2)", "Digits given to toFixed() must not be negative! Digits given -2"},
+		{"5.nope()", "Failed to invoke method: nope"},
+	})
+}
@@ -0,0 +1,39 @@
+package evaluator
+
+import "testing"
+
+func TestFreeze(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var xs = freeze([1, 2]); println(xs.map(x => x * 2))", "[2, 4]\n"},
+		{"var xs = freeze([3, 1]); println(xs.sortBy((a, b) => a - b))", "[1, 3]\n"},
+		{"var xs = freeze([1]); var ys = xs.map(x => x); ys.append(2); println(xs, ys)", "[1]\n[1, 2]\n"},
+		{`var h = freeze({"a": 1}); var m = h.merge({"b": 2}); m.delete("a"); println(h, m)`, "{a: 1}\n{b: 2}\n"},
+		{`var h = freeze({"a": 1}); println(h.get("a"), h.has("a"), h.size())`, "1\ntrue\n1\n"},
+		{"println(freeze(5), freeze(\"s\"))", "5\ns\n"},
+	})
+	testErrors(t, []outputTest{
+		{"var xs = freeze([1]); xs.append(2)", "Cannot append() to a frozen array!"},
+		{"var xs = freeze([1]); xs.detach(0, 0)", "Cannot detach() from a frozen array!"},
+		{`var h = freeze({"a": 1}); h.delete("a")`, "Cannot delete() from a frozen hash!"},
+		{"var xs = freeze([[1]]); xs[0].append(2)", "Cannot append() to a frozen array!"},
+		{`var h = freeze({"a": [1]}); h["a"].append(2)`, "Cannot append() to a frozen array!"},
+		{`var xs = freeze([{"a": 1}]); xs[0].delete("a")`, "Cannot delete() from a frozen hash!"},
+		{"enum Box { Of(v) }; var b = freeze(Box.Of([1])); b.v.append(2)", "Cannot append() to a frozen array!"},
+	})
+}
+
+func TestConstBindings(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"const x = 1; println(x)", "1\n"},
+		{"const [a, b] = [1, 2]; println(a + b)", "3\n"},
+		{"const x = 1; var f = func(x) { return x; }; println(f(2), x)", "2\n1\n"},
+		{"const xs = [1]; xs.append(2); println(xs)", "[1, 2]\n"},
+	})
+	testErrors(t, []outputTest{
+		{"const x = 1; mut x = 2", "Cannot assign to constant `x`"},
+		{"const x = 1; x++", "Cannot assign to constant `x`"},
+		{"const x = 1; var f = func() { mut x = 2; }; f()", "Cannot assign to constant `x`"},
+		{"const [a, b] = [1, 2]; mut [a, b] = [3, 4]", "Cannot assign to constant `a`"},
+		{"const x = 1; var x = 2", "Variable `x` already defined"},
+	})
+}
@@ -0,0 +1,96 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+func TestDefaultAndRestParameters(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var f = func(a, b = 2) { a + b }; println(f(1)); println(f(1, 5))", "3\n6\n"},
+		{"var f = func(a, b = a * 2) { b }; println(f(3))", "6\n"},
+		{"var n = 0; var f = func(a = n + 1) { a }; mut n = 5; println(f())", "6\n"},
+		{"var f = func(first, ...rest) { rest }; println(f(1, 2, 3)); println(f(1))", "[2, 3]\n[]\n"},
+	})
+}
+
+func TestSpreadArguments(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var f = func(a, b) { a + b }; var xs = [1, 2]; println(f(...xs))", "3\n"},
+		{"var f = func(a, b, c) { a + b + c }; println(f(1, ...[2, 3]))", "6\n"},
+		{"var f = func(...all) { all }; println(f(...[1], 2, ...[3]))", "[1, 2, 3]\n"},
+		{`println(len(...["ab"]))`, "2\n"},
+		{"println([0, ...[1, 2], 3])", "[0, 1, 2, 3]\n"},
+	})
+	testErrors(t, []outputTest{
+		{"var f = func(a) { a }; f(...5)", "cannot spread INTEGER"},
+	})
+}
+
+func TestArityErrors(t *testing.T) {
+	testErrors(t, []outputTest{
+		{"var f = func(a, b) { a }; f(1)", "wrong number of arguments. got=1, want=2"},
+		{"var f = func(a) { a }; f(1, 2)", "wrong number of arguments. got=2, want=1"},
+		{"var f = func(a, b = 2) { a }; f()", "wrong number of arguments. got=0, want=1 to 2"},
+		{"var f = func(a, b = 2) { a }; f(1, 2, 3)", "wrong number of arguments. got=3, want=1 to 2"},
+		{"var f = func(a, ...r) { a }; f()", "wrong number of arguments. got=0, want at least 1"},
+		{"var f = func(a, b = 1 / 0) { a }; f(1)", "division by zero: 1 / 0"},
+	})
+}
+
+func TestNamedArguments(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`var connect = func(host, port = 80, timeout = 10) { println(host + ":" + str(port) + "/" + str(timeout)) }
+connect(host: "db", timeout: 30)
+connect("x", port: 1)`, "db:80/30\nx:1/10\n"},
+		{"var f = func(a, b) { a - b }; println(f(b: 1, a: 5))", "4\n"},
+		{`print(1, 2, sep: ", ")`, "1, 2\n"},
+	})
+	testErrors(t, []outputTest{
+		{"var f = func(a) { a }; f(b: 1)", "unknown named argument `b`"},
+		{"var f = func(a) { a }; f(a: 1, a: 2)", "duplicate named argument `a`"},
+		{"var f = func(a) { a }; f(1, a: 2)", "got multiple values for argument `a`"},
+		{"var f = func(a, ...r) { r }; f(1, 2, a: 3)", "got multiple values for argument `a`"},
+		{"len(x: 1)", "builtin function does not accept named arguments, got `x`"},
+	})
+}
+
+func TestPositionalAfterNamedArgumentReportsNamedToken(t *testing.T) {
+	named := &ast.NamedArgument{
+		Token: token.Token{Type: token.IDENTIFIER, Literal: "a", Line: 3},
+		Name:  &ast.Identifier{Token: token.Token{Type: token.IDENTIFIER, Literal: "a", Line: 3}, Value: "a"},
+		Value: &ast.IntegerLiteral{Token: token.Token{Type: token.INT, Literal: "1", Line: 3}, Value: 1},
+	}
+	positional := &ast.IntegerLiteral{Token: token.Token{Type: token.INT, Literal: "2", Line: 3}, Value: 2}
+	_, _, err := evalArguments([]ast.Expression{named, positional}, object.NewEnvironment())
+	if err, ok := err.(*object.Error); !ok || err.Token != named.Token {
+		t.Fatalf("got %v, want an error at %v", err, named.Token)
+	}
+}
+
+func TestArrowFunctions(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println([1, 2].map(x => x * 2))", "[2, 4]\n"},
+		{"println([1, 2].map((x) => x + 1))", "[2, 3]\n"},
+		{"var add = (a, b = 10) => a + b; println(add(1)); println(add(1, 2))", "11\n3\n"},
+		{"var all = (first, ...rest) => rest; println(all(1, 2, 3))", "[2, 3]\n"},
+		{"var f = () => 42; println(f())", "42\n"},
+		{"var swap = ([a, b]) => [b, a]; println(swap([1, 2]))", "[2, 1]\n"},
+		{`var name = ({name, age: years}) => name + str(years); println(name({"name": "x", "age": 3}))`, "x3\n"},
+		{`var nested = ([{x}, [y, ...zs]]) => x + y + len(zs); println(nested([{"x": 1}, [2, 3, 4]]))`, "5\n"},
+		{`var h = {"f": x => x * 3}; println(h["f"](2))`, "6\n"},
+		{"var curry = a => b => a + b; println(curry(1)(2))", "3\n"},
+		{"var blk = x => { return x + 1 }; println(blk(1))", "2\n"},
+		{"println((x => x)(5))", "5\n"},
+		{"var add = (a, b) => a + b; println(add)", "func(a, b) {\nreturn (a + b);\n}\n"},
+	})
+	testErrors(t, []outputTest{
+		{"[1, 2].map(x => x / 0)", "division by zero: 1 / 0"},
+	})
+	testOutput(t, []outputTest{
+		{"var ys = [1, 2].map(x => x / 0)\nprintln(\"unreachable\")", "Error: `division by zero: 1 / 0`\n\tat test.jak: 1\n"},
+	})
+}
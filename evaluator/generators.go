@@ -0,0 +1,34 @@
+package evaluator
+
+import (
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+)
+
+var GENERATOR_CLOSED = &object.Error{Message: "generator closed"}
+
+func newGenerator(fn *object.Function, env *object.Environment) *object.Generator {
+	return object.NewGenerator(fn, env, func() object.Object {
+		result := Eval(fn.Body, env)
+		if result == GENERATOR_CLOSED {
+			return NULL
+		}
+		return result
+	})
+}
+
+func evalYieldExpression(ye *ast.YieldExpression, env *object.Environment) object.Object {
+	generator, ok := env.Generator()
+	if !ok {
+		return newError("yield outside of a generator", ye.Token)
+	}
+
+	value := Eval(ye.Value, env)
+	if isError(value) {
+		return value
+	}
+	if !generator.Yield(value) {
+		return GENERATOR_CLOSED
+	}
+	return NULL
+}
@@ -0,0 +1,51 @@
+package evaluator
+
+import "testing"
+
+func TestGenerators(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var count = func(n) { var i = 0; for (i >= n) { yield i; mut i = i + 1 } }; foreach x in count(3) { println(x) }",
+			"0\n1\n2\n"},
+		{"var g = func() { yield 1; yield 2 }(); println(g.next()); println(g.next()); println(g.next()); println(g.done())",
+			"1\n2\nnull\ntrue\n"},
+		{"var g = func() { yield 1; yield 2; yield 3 }(); println(g.toArray())", "[1, 2, 3]\n"},
+		{`var g = func() { yield 1; println("resumed"); yield 2 }(); println(g.next()); g.close(); println(g.done()); println(g.next())`,
+			"1\ntrue\nnull\n"},
+		{`var g = func() { println("started"); yield 1 }(); println(typeof(g))`, "GENERATOR\n"},
+	})
+}
+
+func TestGeneratorEarlyExit(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`var naturals = func() { var i = 0; for (false) { yield i; mut i = i + 1 } }
+var firstOver = func(limit) { foreach n in naturals() { if (n * n > limit) { return n } } }
+println(firstOver(50))`, "8\n"},
+		{`var naturals = func() { var i = 0; for (false) { yield i; mut i = i + 1 } }
+var nat = naturals()
+var take = func(gen) { foreach v in gen { if (v == 2) { return v } } }
+println(take(nat)); println(nat.done()); println(nat.next())`, "2\ntrue\nnull\n"},
+	})
+}
+
+func TestGeneratorErrors(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`var broken = func() { yield 1; yield 1 / 0 }; foreach v in broken() { println(v) }; println("after")`,
+			"1\nError: `division by zero: 1 / 0`\n\tat test.jak: 1\nafter\n"},
+		{`var bad = func() { yield 1; var y = 1 / 0; yield 2 }(); println(bad.next()); println(bad.next()); println(bad.done())`,
+			"1\nError: `division by zero: 1 / 0`\n\tat test.jak: 1\ntrue\n"},
+	})
+	testErrors(t, []outputTest{
+		{`var f = func() { yield 1; var y = 1 / 0 }; foreach v in f() { }`, "division by zero: 1 / 0"},
+	})
+}
+
+func TestGeneratorReentrancy(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var gg = null\nvar rec = func() { yield gg.next() }\nmut gg = rec()\nprintln(gg.next())\nprintln(gg.done())",
+			"Error: `Generator is already running!`\n\tat test.jak: 2\nnull\ntrue\n"},
+		{"var gg = null\nvar rec = func() { foreach v in gg { yield v } }\nmut gg = rec()\nprintln(gg.next())",
+			"Error: `Generator is already running!`\n\tat test.jak: 2\nnull\n"},
+		{"var gg = null\nvar rec = func() { yield 1; gg.close(); yield 2 }\nmut gg = rec()\nprintln(gg.next())\nprintln(gg.next())\nprintln(gg.done())",
+			"1\nnull\ntrue\n"},
+	})
+}
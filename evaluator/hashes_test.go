@@ -0,0 +1,38 @@
+package evaluator
+
+import "testing"
+
+func TestHashInsertionOrder(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`println({"b": 1, "a": 2, 3: "c"})`, "{b: 1, a: 2, 3: c}\n"},
+		{`var h = {"b": 1, "a": 2, 3: "c"}; println(h.keys()); println(h.values())`, "[b, a, 3]\n[1, 2, c]\n"},
+		{`foreach key in {"z": 1, "y": 2, "x": 3} { println(key) }`, "z\ny\nx\n"},
+		{`println({"a": 1, "b": 2, "a": 3})`, "{a: 3, b: 2}\n"},
+		{`var h = {"a": 1, "b": 2, "c": 3}; h.delete("a"); println(h.merge({"a": 4}))`, "{b: 2, c: 3, a: 4}\n"},
+		{`var key = func(k) { println(k); k }; var h = {key("a"): 1, key("b"): 2, key("c"): 3}`, "a\nb\nc\n"},
+	})
+}
+
+func TestHashMethods(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`var h = {"a": 1, "b": null}; println(h.has("a")); println(h.has("b")); println(h.has("c"))`, "true\ntrue\nfalse\n"},
+		{`var h = {"a": 1, "b": null}; println(h.get("a", 0)); println(h.get("b", 0)); println(h.get("c", 0)); println(h.get("c"))`, "1\nnull\n0\nnull\n"},
+		{`var h = {"a": 1, "b": 2}; println(h.delete("a")); println(h.delete("a")); println(h)`, "true\nfalse\n{b: 2}\n"},
+		{`println({"a": {"x": 1}, "b": 1}.merge({"a": {"y": 2}}))`, "{a: {y: 2}, b: 1}\n"},
+		{`println({"a": {"x": 1}, "b": 1}.merge({"a": {"y": 2}}, true))`, "{a: {x: 1, y: 2}, b: 1}\n"},
+		{`var base = {"a": {"x": 1}}; var m = base.merge({"a": {"y": 2}}, true); println(base)`, "{a: {x: 1}}\n"},
+		{`println({"a": 1, "b": 2}.entries())`, "[[a, 1], [b, 2]]\n"},
+		{`println({"a": 1, "b": 2}.size()); println({}.size())`, "2\n0\n"},
+		{`println({"a": 1, "b": 2, "c": 3}.filter(func(k, v) { v != 2 }))`, "{a: 1, c: 3}\n"},
+		{`println({"a": 1, "b": 2}.mapValues(func(v) { v * 10 }))`, "{a: 10, b: 20}\n"},
+		{`var h = {"a": 1, "b": 2, "c": 3}; foreach k in h { h.delete("b"); println(k) }`, "a\nc\n"},
+	})
+	testErrors(t, []outputTest{
+		{`{"a": 1}.has()`, "Missing argument to has()!"},
+		{`{"a": 1}.has([1])`, "Argument to has() is unusable as hash key: ARRAY"},
+		{`{"a": 1}.merge(1)`, "First argument to merge() must be a hash!"},
+		{`{"a": 1}.merge({}, 1)`, "Second argument to merge() must be a boolean!"},
+		{`{"a": 1}.filter(3)`, "First argument to filter() must be a function!"},
+		{`{"a": 1}.mapValues(func(v) { return v / 0 })`, "division by zero: 1 / 0"},
+	})
+}
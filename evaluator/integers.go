@@ -0,0 +1,200 @@
+package evaluator
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+const maxShiftCount = 1 << 24
+
+func bigIntegerToObject(value *big.Int) object.Object {
+	return object.NewIntegerFromBig(value)
+}
+
+func toBigInt(obj object.Object) *big.Int {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return big.NewInt(obj.Value)
+	case *object.BigInteger:
+		return obj.Value
+	default:
+		return nil
+	}
+}
+
+func integerToFloat(obj object.Object) float64 {
+	switch obj := obj.(type) {
+	case *object.Integer:
+		return float64(obj.Value)
+	case *object.BigInteger:
+		f, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return f
+	default:
+		return 0
+	}
+}
+
+func addInt64(x, y int64) (int64, bool) {
+	sum := x + y
+	if (x >= 0) == (y >= 0) && (sum >= 0) != (x >= 0) {
+		return 0, false
+	}
+	return sum, true
+}
+
+func subInt64(x, y int64) (int64, bool) {
+	diff := x - y
+	if (x >= 0) != (y >= 0) && (diff >= 0) != (x >= 0) {
+		return 0, false
+	}
+	return diff, true
+}
+
+func mulInt64(x, y int64) (int64, bool) {
+	if x == 0 || y == 0 {
+		return 0, true
+	}
+	if (x == -1 && y == math.MinInt64) || (y == -1 && x == math.MinInt64) {
+		return 0, false
+	}
+	product := x * y
+	if product/y != x {
+		return 0, false
+	}
+	return product, true
+}
+
+func powInt64(x, y int64) (int64, bool) {
+	result := int64(1)
+	for y > 0 {
+		var ok bool
+		if y&1 == 1 {
+			if result, ok = mulInt64(result, x); !ok {
+				return 0, false
+			}
+		}
+		y >>= 1
+		if y > 0 {
+			if x, ok = mulInt64(x, x); !ok {
+				return 0, false
+			}
+		}
+	}
+	return result, true
+}
+
+func shiftLeftInt64(x, n int64) (int64, bool) {
+	if n < 0 || n > 62 {
+		return 0, x == 0 && n >= 0
+	}
+	shifted := x << uint(n)
+	if shifted>>uint(n) != x {
+		return 0, false
+	}
+	return shifted, true
+}
+
+func negativePowInt(x, y int64, token token.Token) object.Object {
+	switch {
+	case x == 0:
+		return newError("division by zero: 0 ^ %d", token, y)
+	case x == 1:
+		return &object.Integer{Value: 1}
+	case x == -1 && y%2 == 0:
+		return &object.Integer{Value: 1}
+	case x == -1:
+		return &object.Integer{Value: -1}
+	default:
+		return newError("negative exponent: %d ^ %d", token, x, y)
+	}
+}
+
+// powTooLarge reports whether base ^ exponent would grow past maxShiftCount bits.
+func powTooLarge(base *big.Int, exponent int64) bool {
+	if base.CmpAbs(big.NewInt(1)) <= 0 {
+		return false
+	}
+	return exponent > maxShiftCount/int64(base.BitLen())
+}
+
+// decimalPowTooLarge also bounds the unrounded scale, which costs about four bits a digit.
+func decimalPowTooLarge(base *object.Decimal, exponent int64) bool {
+	if exponent == 0 {
+		return false
+	}
+	return powTooLarge(base.Value, exponent) || int64(base.Scale) > maxShiftCount/4/exponent
+}
+
+func evalBigIntegerInfixExpression(
+	operator string,
+	leftVal, rightVal *big.Int,
+	token token.Token,
+) object.Object {
+	switch operator {
+	case "+":
+		return bigIntegerToObject(new(big.Int).Add(leftVal, rightVal))
+	case "-":
+		return bigIntegerToObject(new(big.Int).Sub(leftVal, rightVal))
+	case "*":
+		return bigIntegerToObject(new(big.Int).Mul(leftVal, rightVal))
+	case "/":
+		if rightVal.Sign() == 0 {
+			return newError("division by zero: %s / 0", token, leftVal.String())
+		}
+		return bigIntegerToObject(new(big.Int).Quo(leftVal, rightVal))
+	case "%":
+		if rightVal.Sign() == 0 {
+			return newError("modulo by zero: %s %% 0", token, leftVal.String())
+		}
+		return bigIntegerToObject(new(big.Int).Rem(leftVal, rightVal))
+	case "^":
+		if !rightVal.IsInt64() {
+			return newError("exponent too large: %s", token, rightVal.String())
+		}
+		if rightVal.Sign() < 0 {
+			if !leftVal.IsInt64() {
+				return newError("negative exponent: %s ^ %s", token, leftVal.String(), rightVal.String())
+			}
+			return negativePowInt(leftVal.Int64(), rightVal.Int64(), token)
+		}
+		if powTooLarge(leftVal, rightVal.Int64()) {
+			return newError("exponent too large: %s", token, rightVal.String())
+		}
+		return bigIntegerToObject(new(big.Int).Exp(leftVal, rightVal, nil))
+	case "&":
+		return bigIntegerToObject(new(big.Int).And(leftVal, rightVal))
+	case "|":
+		return bigIntegerToObject(new(big.Int).Or(leftVal, rightVal))
+	case "~":
+		return bigIntegerToObject(new(big.Int).Xor(leftVal, rightVal))
+	case "<<", ">>":
+		if rightVal.Sign() < 0 {
+			return newError("negative shift count: %s", token, rightVal.String())
+		}
+		if !rightVal.IsInt64() || rightVal.Int64() > maxShiftCount {
+			return newError("shift count too large: %s", token, rightVal.String())
+		}
+		if operator == "<<" {
+			return bigIntegerToObject(new(big.Int).Lsh(leftVal, uint(rightVal.Int64())))
+		}
+		return bigIntegerToObject(new(big.Int).Rsh(leftVal, uint(rightVal.Int64())))
+	case "<":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) < 0)
+	case ">":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) > 0)
+	case "==":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) == 0)
+	case "!=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) != 0)
+	case "<=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) <= 0)
+	case ">=":
+		return nativeBoolToBooleanObject(leftVal.Cmp(rightVal) >= 0)
+	default:
+		return newError("unknown operator: %s %s %s", token,
+			object.INTEGER_OBJ, operator, object.INTEGER_OBJ)
+	}
+}
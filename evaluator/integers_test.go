@@ -0,0 +1,74 @@
+package evaluator
+
+import "testing"
+
+func TestBigIntegerPromotion(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println(2 ^ 70)", "1180591620717411303424\n"},
+		{"println(9223372036854775807 + 1)", "9223372036854775808\n"},
+		{"println(-9223372036854775807 - 2)", "-9223372036854775809\n"},
+		{"println(3037000500 * 3037000500)", "9223372037000250000\n"},
+		{"println((2 ^ 70) % 1000)", "424\n"},
+		{"println((2 ^ 70) / (2 ^ 69))", "2\n"},
+		{"println((2 ^ 70) - (2 ^ 70) + 5)", "5\n"},
+		{"println(-(2 ^ 63))", "-9223372036854775808\n"},
+		{"println(typeof(2 ^ 70))", "INTEGER\n"},
+		{"println(2 ^ 70 > 2 ^ 69)", "true\n"},
+		{`println(2 ^ 64 == int("18446744073709551616"))`, "true\n"},
+	})
+}
+
+func TestBigIntegerConversions(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`println(int("123456789012345678901234567890") + 1)`, "123456789012345678901234567891\n"},
+		{"println(str(2 ^ 64))", "18446744073709551616\n"},
+		{"println(len(str(10 ^ 30)))", "31\n"},
+	})
+}
+
+func TestIntegerDivisionByZero(t *testing.T) {
+	testErrors(t, []outputTest{
+		{"10 / 0", "division by zero: 10 / 0"},
+		{"10 % 0", "modulo by zero: 10 % 0"},
+		{"(2 ^ 70) / 0", "division by zero: 1180591620717411303424 / 0"},
+	})
+}
+
+func TestIntegerPower(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println(1 ^ -3)", "1\n"},
+		{"println(-1 ^ -3)", "-1\n"},
+		{"println((-1) ^ -2)", "1\n"},
+		{"println(1 ^ 100000000000)", "1\n"},
+		{"println(len(str(2 ^ 1000)))", "302\n"},
+	})
+	testErrors(t, []outputTest{
+		{"2 ^ -1", "negative exponent: 2 ^ -1"},
+		{"10 ^ -2", "negative exponent: 10 ^ -2"},
+		{"(2 ^ 70) ^ -1", "negative exponent: 1180591620717411303424 ^ -1"},
+		{"0 ^ -1", "division by zero: 0 ^ -1"},
+		{"3 ^ 100000000000", "exponent too large: 100000000000"},
+		{"(2 ^ 70) ^ 10000000", "exponent too large: 10000000"},
+	})
+}
+
+func TestBitwiseOperators(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println(6 & 3)", "2\n"},
+		{"println(6 | 3)", "7\n"},
+		{"println(6 ~ 3)", "5\n"},
+		{"println(~5)", "-6\n"},
+		{"println(1 << 10)", "1024\n"},
+		{"println(-16 >> 2)", "-4\n"},
+		{"println(1 | 2 & 3)", "3\n"},
+		{"println(1 << 64)", "18446744073709551616\n"},
+		{"println((2 ^ 70) & 0xFF)", "0\n"},
+		{"println(((2 ^ 70) | 1) >> 69)", "2\n"},
+		{"println(0xF0 & 0b1010_0000)", "160\n"},
+	})
+	testErrors(t, []outputTest{
+		{"1 << -1", "negative shift count: -1"},
+		{"1.5 & 1", "unknown operator: FLOAT & INTEGER"},
+		{"~1.5", "unknown operator: ~FLOAT"},
+	})
+}
@@ -0,0 +1,22 @@
+package evaluator
+
+import "testing"
+
+func TestNestedForeach(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`var xs = [1, 2]; foreach a in xs { foreach b in xs { println(a * 10 + b) } }`, "11\n12\n21\n22\n"},
+		{`var h = {"a": 1, "b": 2}; foreach x in h { foreach y in h { println(x + y) } }`, "aa\nab\nba\nbb\n"},
+		{`var s = "ab"; foreach x in s { foreach y in s { println(x + y) } }`, "aa\nab\nba\nbb\n"},
+		{`var xs = [1, 2, 3]
+var sum = func(depth) { var total = 0; foreach x in xs { if (depth > 0) { mut total = total + sum(depth - 1) } else { mut total = total + x } }; return total }
+println(sum(1))`, "18\n"},
+	})
+}
+
+func TestForeachMutation(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var xs = [1, 2, 3]; foreach x in xs { xs.append(x) }; println(xs)", "[1, 2, 3, 1, 2, 3]\n"},
+		{"var xs = [1, 2, 3, 4]; foreach x in xs { if (x == 1) { xs.detach(0, 0) }; println(x) }", "1\n3\n4\n"},
+		{`var h = {"a": 1, "b": 2, "c": 3}; foreach k in h { h.delete("b"); println(k) }`, "a\nc\n"},
+	})
+}
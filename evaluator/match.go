@@ -0,0 +1,251 @@
+package evaluator
+
+import (
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+var patternTypes = map[string]bool{
+	object.INTEGER_OBJ:   true,
+	object.FLOAT_OBJ:     true,
+	object.DECIMAL_OBJ:   true,
+	object.STRING_OBJ:    true,
+	object.BOOLEAN_OBJ:   true,
+	object.ARRAY_OBJ:     true,
+	object.HASH_OBJ:      true,
+	object.RANGE_OBJ:     true,
+	object.NULL_OBJ:      true,
+	object.FUNCTION_OBJ:  true,
+	object.BUILTIN_OBJ:   true,
+	object.GENERATOR_OBJ: true,
+	object.FILE_OBJ:      true,
+}
+
+func evalMatchExpression(me *ast.MatchExpression, env *object.Environment) object.Object {
+	subject := Eval(me.Subject, env)
+	if isError(subject) {
+		return subject
+	}
+
+	for _, arm := range me.Arms {
+		bindings, matched, err := matchPattern(arm.Pattern, subject, nil, env, me.Token)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		armEnv := object.NewEnclosedEnvironment(env)
+		if err := declarePatternBindings(bindings, arm.Pattern, armEnv); err != nil {
+			return err
+		}
+
+		if arm.Guard != nil {
+			guard := Eval(arm.Guard, armEnv)
+			if isError(guard) {
+				return guard
+			}
+			if !isTruthy(guard) {
+				continue
+			}
+		}
+		return Eval(arm.Body, armEnv)
+	}
+
+	return newError("no match arm matched %s", me.Token, subject.Inspect())
+}
+
+func declarePatternBindings(bindings []binding, pattern ast.Expression, env *object.Environment) *object.Error {
+	seen := make(map[string]bool, len(bindings))
+	for _, b := range bindings {
+		if seen[b.name.Value] {
+			return newError("Variable `%s` bound more than once in %s", b.name.Token, b.name.Value, pattern.String())
+		}
+		seen[b.name.Value] = true
+		declareBinding(b.name, b.value, env, false)
+	}
+	return nil
+}
+
+func matchPattern(pattern ast.Expression, value object.Object, bindings []binding, env *object.Environment, at token.Token) ([]binding, bool, *object.Error) {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		if pattern.Value == "_" {
+			return bindings, true, nil
+		}
+		return append(bindings, binding{name: pattern, value: value}), true, nil
+	case *ast.TypePattern:
+		if !patternTypes[pattern.TypeName] {
+			return nil, false, newError("unknown type in pattern: %s", pattern.Token, pattern.TypeName)
+		}
+		if string(value.Type()) != pattern.TypeName {
+			return bindings, false, nil
+		}
+		return matchPattern(pattern.Name, value, bindings, env, pattern.Token)
+	case *ast.LiteralPattern:
+		literal := Eval(pattern.Value, nil)
+		if isError(literal) {
+			return nil, false, literal.(*object.Error)
+		}
+		return bindings, valuesEqual(literal, value, pattern.Token), nil
+	case *ast.RangePattern:
+		return bindings, inRangePattern(pattern, value), nil
+	case *ast.ArrayPattern:
+		return matchArrayPattern(pattern, value, bindings, env)
+	case *ast.HashPattern:
+		return matchHashPattern(pattern, value, bindings, env)
+	case *ast.VariantPattern:
+		return matchEnumPattern(pattern, value, bindings, env)
+	default:
+		return nil, false, newError("invalid pattern: %s", at, pattern.String())
+	}
+}
+
+func matchArrayPattern(pattern *ast.ArrayPattern, value object.Object, bindings []binding, env *object.Environment) ([]binding, bool, *object.Error) {
+	array, ok := value.(*object.Array)
+	if !ok {
+		return bindings, false, nil
+	}
+	if len(array.Elements) < len(pattern.Elements) ||
+		(pattern.Rest == nil && len(array.Elements) != len(pattern.Elements)) {
+		return bindings, false, nil
+	}
+
+	for i, element := range pattern.Elements {
+		var matched bool
+		var err *object.Error
+		if bindings, matched, err = matchPattern(element, array.Elements[i], bindings, env, pattern.Token); err != nil || !matched {
+			return bindings, matched, err
+		}
+	}
+
+	if pattern.Rest == nil {
+		return bindings, true, nil
+	}
+	rest := make([]object.Object, len(array.Elements)-len(pattern.Elements))
+	copy(rest, array.Elements[len(pattern.Elements):])
+	return matchPattern(pattern.Rest, &object.Array{Elements: rest}, bindings, env, pattern.Token)
+}
+
+func matchHashPattern(pattern *ast.HashPattern, value object.Object, bindings []binding, env *object.Environment) ([]binding, bool, *object.Error) {
+	hash, ok := value.(*object.Hash)
+	if !ok {
+		return bindings, false, nil
+	}
+
+	used := make(map[object.HashKey]bool, len(pattern.Pairs))
+	for _, pair := range pattern.Pairs {
+		hashKey := (&object.String{Value: pair.Key}).HashKey()
+		entry, ok := hash.Get(hashKey)
+		if !ok {
+			return bindings, false, nil
+		}
+		used[hashKey] = true
+
+		var matched bool
+		var err *object.Error
+		if bindings, matched, err = matchPattern(pair.Target, entry.Value, bindings, env, pattern.Token); err != nil || !matched {
+			return bindings, matched, err
+		}
+	}
+
+	if pattern.Rest == nil {
+		return bindings, true, nil
+	}
+	rest := object.NewHash()
+	for _, entry := range hash.Pairs() {
+		hashKey := entry.Key.(object.Hashable).HashKey()
+		if !used[hashKey] {
+			rest.Set(hashKey, entry)
+		}
+	}
+	return matchPattern(pattern.Rest, rest, bindings, env, pattern.Token)
+}
+
+func matchEnumPattern(pattern *ast.VariantPattern, value object.Object, bindings []binding, env *object.Environment) ([]binding, bool, *object.Error) {
+	obj := Eval(pattern.Enum, env)
+	if isError(obj) {
+		return nil, false, obj.(*object.Error)
+	}
+	enum, ok := obj.(*object.Enum)
+	if !ok {
+		return nil, false, newError("%s is not an enum: %s", pattern.Token, pattern.Enum.Value, obj.Type())
+	}
+	variant, ok := enum.Variant(pattern.Variant.Value)
+	if !ok {
+		return nil, false, newError("enum %s has no variant `%s`", pattern.Variant.Token, enum.Name, pattern.Variant.Value)
+	}
+	if _, unit := variant.Unit(); unit && pattern.Payload {
+		return nil, false, newError("%s has no payload, match it without parentheses", pattern.Token, variant)
+	} else if !unit && !pattern.Payload {
+		return nil, false, newError("%s needs a payload: %s(...)", pattern.Token, variant, variant)
+	}
+	if len(pattern.Fields) != len(variant.Fields) {
+		return nil, false, newError("pattern %s takes %d payload value(s), got %d",
+			pattern.Token, variant, len(variant.Fields), len(pattern.Fields))
+	}
+
+	enumValue, ok := value.(*object.EnumValue)
+	if !ok || enumValue.Variant != variant {
+		return bindings, false, nil
+	}
+	for i, field := range pattern.Fields {
+		var matched bool
+		var err *object.Error
+		if bindings, matched, err = matchPattern(field, enumValue.Values[i], bindings, env, pattern.Token); err != nil || !matched {
+			return bindings, matched, err
+		}
+	}
+	return bindings, true, nil
+}
+
+func isNumber(obj object.Object) bool {
+	switch obj.Type() {
+	case object.INTEGER_OBJ, object.FLOAT_OBJ, object.DECIMAL_OBJ:
+		return true
+	default:
+		return false
+	}
+}
+
+func valuesEqual(left, right object.Object, token token.Token) bool {
+	switch {
+	case isNumber(left) && isNumber(right):
+		return evalInfixExpression("==", left, right, nil, token) == TRUE
+	case left.Type() == object.STRING_OBJ && right.Type() == object.STRING_OBJ:
+		return left.(*object.String).Value == right.(*object.String).Value
+	case left.Type() != right.Type():
+		return false
+	case left.Type() == object.NULL_OBJ:
+		return true
+	case left.Type() == object.ENUM_VALUE_OBJ:
+		return enumValuesEqual(left.(*object.EnumValue), right.(*object.EnumValue), token)
+	}
+
+	leftKey, leftOk := left.(object.Hashable)
+	rightKey, rightOk := right.(object.Hashable)
+	if leftOk && rightOk {
+		return leftKey.HashKey() == rightKey.HashKey()
+	}
+	return left == right
+}
+
+func inRangePattern(pattern *ast.RangePattern, value object.Object) bool {
+	if !isNumber(value) {
+		return false
+	}
+	low := Eval(pattern.Low, nil)
+	high := Eval(pattern.High, nil)
+	if !isNumber(low) || !isNumber(high) {
+		return false
+	}
+
+	upper := "<"
+	if pattern.Inclusive {
+		upper = "<="
+	}
+	return evalInfixExpression(">=", value, low, nil, pattern.Token) == TRUE &&
+		evalInfixExpression(upper, value, high, nil, pattern.Token) == TRUE
+}
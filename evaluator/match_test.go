@@ -0,0 +1,62 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+func TestMatchPatterns(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`println(match (2) { 1 => "one", 2 => "two", _ => "many" })`, "two\n"},
+		{`println(match (7) { 1 => "one", _ => "many" })`, "many\n"},
+		{`println(match (-1) { -1 => "minus one", _ => "other" })`, "minus one\n"},
+		{`println(match (5) { 0..5 => "low", 5..=9 => "high" })`, "high\n"},
+		{`println(match ("a") { n: INTEGER => n, s: STRING => s + "!" })`, "a!\n"},
+		{`println(match ([1, 2, 3]) { [a, ...rest] => rest })`, "[2, 3]\n"},
+		{`println(match ([1, 2]) { [a] => "one", [a, b] => a + b })`, "3\n"},
+		{`println(match ({"x": 1, "y": 2}) { {x, y: 3} => "no", {x, ...rest} => rest })`, "{y: 2}\n"},
+		{`println(match (null) { null => "nothing", _ => "something" })`, "nothing\n"},
+	})
+	testErrors(t, []outputTest{
+		{`match (3) { 1 => "one" }`, "no match arm matched 3"},
+		{`match ([1, 1]) { [a, a] => a }`, "Variable `a` bound more than once in [a, a]"},
+		{`match (1) { n: NUMBER => n }`, "unknown type in pattern: NUMBER"},
+	})
+}
+
+func TestMatchGuards(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`println(match (5) { n if n > 3 => "big", n => "small" })`, "big\n"},
+		{`println(match (2) { n if n > 3 => "big", n => "small" })`, "small\n"},
+		{`println(match (4) { n: INTEGER if n % 2 == 0 => "even", _ => "odd" })`, "even\n"},
+		{`println(match (4) { n if n > 1 && n < 5 => "inside", _ => "outside" })`, "inside\n"},
+		{`println(match ([1, 2]) { [a, b] if a > b => "desc", [a, b] => "asc" })`, "asc\n"},
+		{`var xs = [1, 2]; println(match (2) { n if xs.any(x => x == n) => "found", _ => "missing" })`, "found\n"},
+		{`var n = 10; println(match (1) { n if n > 5 => "outer", n => n })`, "1\n"},
+	})
+	testErrors(t, []outputTest{
+		{`match (1) { n if n / 0 => n }`, "division by zero: 1 / 0"},
+	})
+}
+
+func TestInvalidPatternReportsMatchToken(t *testing.T) {
+	at := token.Token{Type: token.MATCH, Literal: "match", Line: 2}
+	invalid := &ast.CallExpression{Function: &ast.Identifier{Value: "f"}}
+	match := &ast.MatchExpression{
+		Token:   at,
+		Subject: &ast.IntegerLiteral{Value: 1},
+		Arms:    []*ast.MatchArm{{Pattern: invalid, Body: &ast.BlockStatement{}}},
+	}
+	if err, ok := evalMatchExpression(match, object.NewEnvironment()).(*object.Error); !ok || err.Token != at {
+		t.Fatalf("got %v, want an error at %v", err, at)
+	}
+
+	nested := token.Token{Type: token.LBRACKET, Literal: "[", Line: 3}
+	pattern := &ast.ArrayPattern{Token: nested, Elements: []ast.Expression{invalid}}
+	if _, _, err := matchPattern(pattern, &object.Array{Elements: []object.Object{NULL}}, nil, nil, at); err == nil || err.Token != nested {
+		t.Fatalf("got %v, want an error at %v", err, nested)
+	}
+}
@@ -0,0 +1,36 @@
+package evaluator
+
+import "testing"
+
+func TestOptionalChaining(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var a = null; println(a?.b)", "null\n"},
+		{"var a = null; println(a?.b.c)", "null\n"},
+		{"var a = null; println(a?.b.c.d)", "null\n"},
+		{"var a = null; println(a?.b())", "null\n"},
+		{"var a = null; println(a?.m().n())", "null\n"},
+		{"var a = null; println(a?.[0].x)", "null\n"},
+		{"var a = null; println(a?.[1:2].x)", "null\n"},
+		{`var h = {"b": null}; println(h.b?.c.d)`, "null\n"},
+		{`var h = {"b": {"c": 1}}; println(h?.b.c)`, "1\n"},
+		{`var h = {"b": [1, 2]}; println(h?.b.find(2))`, "1\n"},
+		{`var h = {"a": {"b": 1}}; println(h?.a)`, "{b: 1}\n"},
+		{"enum Shape { Circle(r) }; var s = Shape.Circle(2); println(s?.r)", "2\n"},
+	})
+	testErrors(t, []outputTest{
+		{`var h = {"b": null}; h?.b.c`, "index operator not supported: NULL"},
+		{`var h = {"b": {}}; h?.b.c.d`, "index operator not supported: NULL"},
+	})
+}
+
+func TestNullishAndTernary(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var a = null; println(a ?? 5)", "5\n"},
+		{"var a = null; println(a?.b ?? 7)", "7\n"},
+		{"var a = 0; println(a ?? 5)", "0\n"},
+		{"println(null ?? null ?? 3)", "3\n"},
+		{"println(true ? 1 : 2)", "1\n"},
+		{"println(false ? 1 : false ? 2 : 3)", "3\n"},
+		{"var a = null; println(a == null ? \"none\" : a)", "none\n"},
+	})
+}
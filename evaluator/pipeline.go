@@ -0,0 +1,57 @@
+package evaluator
+
+import (
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+)
+
+const PIPELINE_PLACEHOLDER = "_"
+
+func evalPipelineExpression(pe *ast.PipelineExpression, env *object.Environment) object.Object {
+	left := Eval(pe.Left, env)
+	if isError(left) {
+		return left
+	}
+
+	call, ok := pe.Right.(*ast.CallExpression)
+	if !ok {
+		function := Eval(pe.Right, env)
+		if isError(function) {
+			return function
+		}
+		return applyFunction(function, []object.Object{left}, nil, pe.Token)
+	}
+
+	function := Eval(call.Function, env)
+	if isError(function) {
+		return function
+	}
+
+	if !HasPipelinePlaceholder(call.Arguments) {
+		args, named, err := evalArguments(call.Arguments, env)
+		if err != nil {
+			return err
+		}
+		return applyFunction(function, append([]object.Object{left}, args...), named, call.Token)
+	}
+
+	scope := object.NewEnclosedEnvironment(env)
+	scope.Set(PIPELINE_PLACEHOLDER, left)
+	args, named, err := evalArguments(call.Arguments, scope)
+	if err != nil {
+		return err
+	}
+	return applyFunction(function, args, named, call.Token)
+}
+
+func HasPipelinePlaceholder(args []ast.Expression) bool {
+	for _, arg := range args {
+		if named, ok := arg.(*ast.NamedArgument); ok {
+			arg = named.Value
+		}
+		if ident, ok := arg.(*ast.Identifier); ok && ident.Value == PIPELINE_PLACEHOLDER {
+			return true
+		}
+	}
+	return false
+}
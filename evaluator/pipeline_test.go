@@ -0,0 +1,41 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+)
+
+func TestPipelineExpressions(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var double = x => x * 2; println(5 |> double)", "10\n"},
+		{"var double = x => x * 2; println(5 |> double |> double)", "20\n"},
+		{"var add = (a, b) => a + b; println(5 |> add(1))", "6\n"},
+		{"var sub = (a, b) => a - b; println(5 |> sub(10, _))", "5\n"},
+		{"var sub = (a, b) => a - b; println(2 |> sub(b: 1))", "1\n"},
+		{`println("abc" |> len)`, "3\n"},
+		{`println([1, 2, 3] |> len(_))`, "3\n"},
+	})
+	testErrors(t, []outputTest{
+		{`"a" |> 5`, "not a function: INTEGER"},
+		{"var f = x => x; (1 / 0) |> f", "division by zero: 1 / 0"},
+	})
+}
+
+func TestPipelinesInMacros(t *testing.T) {
+	tests := []outputTest{
+		{"var twice = macro(x) { quote(unquote(x) + unquote(x)) }; var r = twice(\"ab\" |> len); println(r)", "4\n"},
+		{"var piped = macro(x) { quote(unquote(x) |> len) }; var r = piped(\"abc\"); println(r)", "3\n"},
+	}
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+		macros := object.NewEnvironment()
+		DefineMacros(program, macros)
+		expanded := ExpandMacros(program, macros).(*ast.Program)
+		got := captureOutput(t, func() { Eval(expanded, object.NewEnvironment()) })
+		if got != tt.expected {
+			t.Errorf("%q: got output %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package evaluator
+
+import "testing"
+
+func TestRangeLiterals(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"foreach i in 0..3 { println(i) }", "0\n1\n2\n"},
+		{"foreach i in 1..=3 { println(i) }", "1\n2\n3\n"},
+		{"foreach i in (0..10).step(4) { println(i) }", "0\n4\n8\n"},
+		{"foreach i in (0..=3).reverse() { println(i) }", "3\n2\n1\n0\n"},
+		{"println(0..5); println(1..=5); println((0..10).step(5))", "0..5\n1..=5\n(0..10).step(5)\n"},
+		{"println((0..10).len()); println((1..=10).len()); println((5..0).len())", "10\n10\n0\n"},
+		{"println((0..10).step(3).contains(9)); println((0..10).step(3).contains(4))", "true\nfalse\n"},
+		{"println((0..4).toArray())", "[0, 1, 2, 3]\n"},
+		{"println(typeof(0..4))", "RANGE\n"},
+	})
+}
+
+func TestRangeMethods(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println((0..5).map(func(x) { x * 2 }))", "[0, 2, 4, 6, 8]\n"},
+		{"println((0..5).filter(func(x) { x > 2 }))", "[3, 4]\n"},
+		{"println((0..5).reduce(func(a, b) { a + b }))", "10\n"},
+		{"println((0..5).reduce(func(a, b) { a + b }, 10))", "20\n"},
+		{"(0..2).forEach(func(x) { println(x) })", "0\n1\n"},
+		{"println((0..1000000000000).any(func(x) { x > 3 }))", "true\n"},
+		{"println((0..1000000000000).all(func(x) { x < 3 }))", "false\n"},
+		{"println((0..10).step(2).find(4)); println((0..10).find(11))", "2\n-1\n"},
+		{`println((0..3).zip(["a", "b"]))`, "[[0, a], [1, b]]\n"},
+		{"println((0..3).sortBy(func(a, b) { b - a }))", "[2, 1, 0]\n"},
+	})
+	testErrors(t, []outputTest{
+		{"var r = 0..3; r.append(9)", "Cannot append() on a range, convert it with toArray() first!"},
+		{"(0..3).detach(0)", "Cannot detach() on a range, convert it with toArray() first!"},
+		{"(0..3).nope()", "Failed to invoke method: nope"},
+		{"(0..0).reduce(func(a, b) { a + b })", "reduce() of an empty range needs an initial value!"},
+		{"(0..3).map(func(x) { return x / 0 })", "division by zero: 0 / 0"},
+		{"(0..3).step(0)", "First argument to step() must be a positive integer!"},
+		{"(0..9223372036854775807).toArray()", "Range 0..9223372036854775807 has 9223372036854775807 elements, too many to convert to an array!"},
+		{"(0..100000000).sortBy(func(a, b) { a - b })", "Range 0..100000000 has 100000000 elements, too many to convert to an array!"},
+		{"(0..100000000).flatMap(func(x) { [x] })", "Range 0..100000000 has 100000000 elements, too many to convert to an array!"},
+		{"(0..100000000).groupBy(func(x) { x })", "Range 0..100000000 has 100000000 elements, too many to convert to an array!"},
+		{"(0..100000000).zip([1])", "Range 0..100000000 has 100000000 elements, too many to convert to an array!"},
+	})
+}
+
+func TestRangeBuiltinReturnsArray(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"println(typeof(range(3))); println(range(3))", "ARRAY\n[0, 1, 2]\n"},
+		{"println(range(1, 4)); println(range(5, 0, -2))", "[1, 2, 3]\n[5, 3, 1]\n"},
+		{"var xs = range(2); xs.append(9); println(xs)", "[0, 1, 9]\n"},
+	})
+	testErrors(t, []outputTest{
+		{"range(1, 2, 0)", "step given to `range` cannot be zero"},
+		{`range("a")`, "argument to `range` must be INTEGER, got STRING"},
+		{"range(9223372036854775807)", "`range` would produce 9223372036854775807 elements, at most 16777216 are allowed"},
+	})
+}
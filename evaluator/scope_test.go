@@ -0,0 +1,23 @@
+package evaluator
+
+import "testing"
+
+func TestBlockScoping(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"if (true) { var t = 1; println(t); } if (true) { var t = 2; println(t); }", "1\n2\n"},
+		{"if (false) { var t = 1; } elif (true) { var t = 2; println(t); } else { var t = 3; }", "2\n"},
+		{"var i = 0; for (i == 3) { var x = i; mut i = i + 1; } println(i)", "3\n"},
+		{"foreach v in [1, 2] { var t = v * 10; print(t); }", "10\n20\n"},
+		{"switch (1) { case 1 { var t = 5; println(t); } } switch (2) { default { var t = 6; println(t); } }", "5\n6\n"},
+		{"var fs = []; foreach v in [1, 2, 3] { fs.append(func() { v }); } println(fs.map(f => f()))", "[1, 2, 3]\n"},
+		{"var fs = []; foreach i, v in [4, 5] { fs.append(func() { i + v }); } println(fs.map(f => f()))", "[4, 6]\n"},
+		{"var t = 1; if (true) { mut t = 2; } println(t)", "2\n"},
+	})
+	testErrors(t, []outputTest{
+		{"if (true) { var t = 1; } t", "identifier not found: t"},
+		{"foreach v in [1] { var t = v; } t", "identifier not found: t"},
+		{"foreach v in [1] { } v", "identifier not found: v"},
+		{"switch (1) { case 1 { var t = 1; } } t", "identifier not found: t"},
+		{"var t = 0; if (true) { var t = 1; }", "Variable `t` already defined"},
+	})
+}
@@ -0,0 +1,54 @@
+package evaluator
+
+import "testing"
+
+func TestArraySlices(t *testing.T) {
+	testOutput(t, []outputTest{
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[1:3])", "[1, 2]\n"},
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[:2])", "[0, 1]\n"},
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[4:])", "[4, 5]\n"},
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[-2:])", "[4, 5]\n"},
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[1:-1])", "[1, 2, 3, 4]\n"},
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[::2])", "[0, 2, 4]\n"},
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[::-1])", "[5, 4, 3, 2, 1, 0]\n"},
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[5:0:-2])", "[5, 3, 1]\n"},
+		{"var xs = [0, 1, 2, 3, 4, 5]; println(xs[-100:2]); println(xs[4:100]); println(xs[3:1])", "[0, 1]\n[4, 5]\n[]\n"},
+		{"var xs = [0, 1, 2]; println(xs[-1]); println(xs[-3])", "2\n0\n"},
+		{"var xs = [0, 1, 2]; var ys = xs[:]; println(len(ys))", "3\n"},
+	})
+	testErrors(t, []outputTest{
+		{"[1, 2, 3][10]", "index out of range: 10 (length 3)"},
+		{"[1, 2, 3][-4]", "index out of range: -4 (length 3)"},
+		{"[1, 2, 3][::0]", "slice step cannot be zero"},
+		{`[1, 2, 3]["a":1]`, "slice indices must be INTEGER, got STRING"},
+		{"5[1:2]", "slice operator not supported: INTEGER"},
+	})
+}
+
+func TestStringSlices(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`println("hello"[1:3])`, "el\n"},
+		{`println("hello"[2:])`, "llo\n"},
+		{`println("héllo"[::-1])`, "olléh\n"},
+		{`println("héllo"[1:3])`, "él\n"},
+		{`println("日本語"[::2])`, "日語\n"},
+		{`println("abc"[5:] == "")`, "true\n"},
+	})
+}
+
+func TestStringIndexing(t *testing.T) {
+	testOutput(t, []outputTest{
+		{`println("abc"[0])`, "a\n"},
+		{`println("héllo"[1])`, "é\n"},
+		{`println("日本語"[2])`, "語\n"},
+		{`println("abc"[-1])`, "c\n"},
+		{`println(typeof("abc"[0]))`, "STRING\n"},
+		{`var s = "héllo"; var out = ""; foreach i in range(len(s)) { mut out = s[i] + out }; println(out)`, "olléh\n"},
+	})
+	testErrors(t, []outputTest{
+		{`"abc"[3]`, "index out of range: 3 (length 3)"},
+		{`"abc"[-4]`, "index out of range: -4 (length 3)"},
+		{`""[0]`, "index out of range: 0 (length 0)"},
+		{`"abc"["x"]`, "index operator not supported: STRING"},
+	})
+}
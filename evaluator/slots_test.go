@@ -0,0 +1,34 @@
+package evaluator
+
+import (
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+func TestMismatchedSlotsReportErrors(t *testing.T) {
+	slotted := func(depth int) *ast.Identifier {
+		return &ast.Identifier{Token: token.Token{Type: token.IDENTIFIER, Literal: "x"}, Value: "x", Resolved: true, Depth: depth}
+	}
+	one := &ast.IntegerLiteral{Token: token.Token{Type: token.INT, Literal: "1"}, Value: 1}
+	mut := token.Token{Type: token.MUTATE, Literal: "mut"}
+
+	tests := []struct {
+		node     ast.Node
+		expected string
+	}{
+		{slotted(0), "identifier not found: x"},
+		{slotted(4), "identifier not found: x"},
+		{&ast.AssignStatement{Token: mut, Name: slotted(4), Value: one}, "Variable `x` not defined"},
+		{&ast.AssignStatement{Token: mut, Pattern: &ast.ArrayPattern{Elements: []ast.Expression{slotted(4)}}, Value: &ast.ArrayLiteral{Elements: []ast.Expression{one}}}, "Variable `x` not defined"},
+		{&ast.PostfixExpression{Token: token.Token{Type: token.IDENTIFIER, Literal: "x"}, Name: slotted(4), Operator: &ast.StringLiteral{Value: "++"}}, "x is unknown"},
+	}
+	for _, tt := range tests {
+		err, ok := Eval(tt.node, object.NewEnclosedEnvironment(object.NewEnvironment())).(*object.Error)
+		if !ok || err.Message != tt.expected {
+			t.Errorf("%s: got %v, want error %q", tt.node.String(), err, tt.expected)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package lexer
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/file"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
 )
 
@@ -20,6 +22,7 @@ type Lexer struct {
 	readPosition int
 	ch           byte
 	line         int
+	errors       []string
 }
 
 func New(input string) *Lexer {
@@ -28,6 +31,10 @@ func New(input string) *Lexer {
 	return l
 }
 
+func (l *Lexer) Errors() []string {
+	return l.errors
+}
+
 func (l *Lexer) readChar() {
 	if l.readPosition >= len(l.input) {
 		l.ch = 0
@@ -53,6 +60,10 @@ func (l *Lexer) NextToken() token.Token {
 			ch := l.ch
 			l.readChar()
 			tok = newToken(token.EQ, l.line, string(ch)+string(l.ch), l.position)
+		} else if l.peekChar() == '>' {
+			ch := l.ch
+			l.readChar()
+			tok = newToken(token.ARROW, l.line, string(ch)+string(l.ch), l.position)
 		} else {
 			tok = newToken(token.ASSIGN, l.line, string(l.ch), l.position)
 		}
@@ -65,7 +76,22 @@ func (l *Lexer) NextToken() token.Token {
 	case ',':
 		tok = newToken(token.COMMA, l.line, string(l.ch), l.position)
 	case '.':
-		tok = newToken(token.DOT, l.line, string(l.ch), l.position)
+		if l.peekChar() == '.' {
+			position := l.position
+			l.readChar()
+			if l.peekChar() == '=' || l.peekChar() == '.' {
+				l.readChar()
+			}
+			tok = newToken(token.DOT_DOT, l.line, l.input[position:l.position+1], l.position)
+			switch tok.Literal {
+			case "..=":
+				tok.Type = token.DOT_DOT_EQ
+			case "...":
+				tok.Type = token.ELLIPSIS
+			}
+		} else {
+			tok = newToken(token.DOT, l.line, string(l.ch), l.position)
+		}
 	case '+':
 		if l.peekChar() == '+' {
 			ch := l.ch
@@ -101,13 +127,23 @@ func (l *Lexer) NextToken() token.Token {
 			ch := l.ch
 			l.readChar()
 			tok = newToken(token.AND, l.line, string(ch)+string(l.ch), l.position)
+		} else {
+			tok = newToken(token.BIT_AND, l.line, string(l.ch), l.position)
 		}
 	case '|':
 		if l.peekChar() == '|' {
 			ch := l.ch
 			l.readChar()
 			tok = newToken(token.OR, l.line, string(ch)+string(l.ch), l.position)
+		} else if l.peekChar() == '>' {
+			ch := l.ch
+			l.readChar()
+			tok = newToken(token.PIPE, l.line, string(ch)+string(l.ch), l.position)
+		} else {
+			tok = newToken(token.BIT_OR, l.line, string(l.ch), l.position)
 		}
+	case '~':
+		tok = newToken(token.TILDE, l.line, string(l.ch), l.position)
 	case '<':
 		tok = newToken(token.LT, l.line, string(l.ch), l.position)
 		if l.peekChar() == '=' {
@@ -115,6 +151,10 @@ func (l *Lexer) NextToken() token.Token {
 			l.readChar()
 			tok = newToken(token.LT_EQ, l.line, string(ch)+string(l.ch), l.position)
 
+		} else if l.peekChar() == '<' {
+			ch := l.ch
+			l.readChar()
+			tok = newToken(token.SHIFT_LEFT, l.line, string(ch)+string(l.ch), l.position)
 		}
 	case '>':
 		tok = newToken(token.GT, l.line, string(l.ch), l.position)
@@ -122,6 +162,10 @@ func (l *Lexer) NextToken() token.Token {
 			ch := l.ch
 			l.readChar()
 			tok = newToken(token.GT_EQ, l.line, string(ch)+string(l.ch), l.position)
+		} else if l.peekChar() == '>' {
+			ch := l.ch
+			l.readChar()
+			tok = newToken(token.SHIFT_RIGHT, l.line, string(ch)+string(l.ch), l.position)
 		}
 	case '{':
 		tok = newToken(token.LBRACE, l.line, string(l.ch), l.position)
@@ -137,6 +181,17 @@ func (l *Lexer) NextToken() token.Token {
 		tok = newToken(token.COLON, l.line, string(l.ch), l.position)
 	case '^':
 		tok = newToken(token.CARET, l.line, string(l.ch), l.position)
+	case '?':
+		if l.peekChar() == '?' || l.peekChar() == '.' {
+			ch := l.ch
+			l.readChar()
+			tok = newToken(token.NULLISH, l.line, string(ch)+string(l.ch), l.position)
+			if l.ch == '.' {
+				tok.Type = token.OPTIONAL_DOT
+			}
+		} else {
+			tok = newToken(token.QUESTION, l.line, string(l.ch), l.position)
+		}
 	case 0:
 		tok = newToken(token.EOF, l.line, string(l.ch), l.position)
 	default:
@@ -148,6 +203,7 @@ func (l *Lexer) NextToken() token.Token {
 		} else if isDigit(l.ch) {
 			return l.readDecimal()
 		} else {
+			l.errorf("unexpected character %q", l.ch)
 			tok = newToken(token.ILLEGAL, l.line, string(l.ch), l.position)
 		}
 	}
@@ -207,7 +263,7 @@ func (l *Lexer) skipWhitespace() {
 
 func (l *Lexer) readNumber() string {
 	position := l.position
-	for isDigit(l.ch) {
+	for isDigit(l.ch) || l.ch == '_' {
 		l.readChar()
 	}
 	return l.input[position:l.position]
@@ -291,28 +347,144 @@ func (l *Lexer) skipSingleLineComment() {
 }
 
 func (l *Lexer) readDecimal() token.Token {
-	integer := l.readNumber()
-	if l.ch == '.' {
+	if l.ch == '0' && isBasePrefix(l.peekChar()) {
+		return l.readPrefixedInteger()
+	}
+
+	position := l.position
+	var tokenType token.TokenType = token.INT
+	literal := l.readNumber()
+	digits := []string{literal}
+	if l.ch == '.' && !isLetter(l.peekChar()) && l.peekChar() != '.' {
 		l.readChar()
 		fraction := l.readNumber()
-		if isEmpty(l.ch) || isWhitespace(l.ch) || isOperator(l.ch) || isComparison(l.ch) || isCompound(l.ch) || isBracket(l.ch) || isBrace(l.ch) || isParen(l.ch) {
-			return newToken(token.FLOAT, l.line, integer+"."+fraction, l.position)
+		literal += "." + fraction
+		digits = append(digits, fraction)
+		tokenType = token.FLOAT
+	}
+	if (l.ch == 'e' || l.ch == 'E') && l.isExponentStart() {
+		exponent := l.readExponent()
+		literal += exponent
+		digits = append(digits, strings.TrimLeft(exponent[1:], "+-"))
+		tokenType = token.FLOAT
+	}
+	if l.ch == 'd' && l.endsNumberAt(l.readPosition) {
+		l.readChar()
+		literal += "d"
+		tokenType = token.DECIMAL
+	}
+
+	if !l.endsNumberAt(l.position) {
+		return l.illegalNumber(position, fmt.Sprintf("invalid character %q in number literal", l.ch))
+	}
+	for _, part := range digits {
+		if !validSeparators(part) {
+			return l.illegalNumber(position, "misplaced digit separator `_` in number literal")
 		}
-		illegalPart := l.readUntilWhitespace()
-		return newToken(token.ILLEGAL, l.line, integer+"."+fraction+illegalPart, l.position)
+	}
+	return newToken(tokenType, l.line, literal, l.position)
+}
 
-	} else if isEmpty(l.ch) || isWhitespace(l.ch) || isOperator(l.ch) || isComparison(l.ch) || isCompound(l.ch) || isBracket(l.ch) || isBrace(l.ch) || isParen(l.ch) {
-		return newToken(token.INT, l.line, integer, l.position)
-	} else {
-		illegalPart := l.readUntilWhitespace()
-		return newToken(token.ILLEGAL, l.line, integer+illegalPart, l.position)
+func (l *Lexer) readPrefixedInteger() token.Token {
+	position := l.position
+	l.readChar()
+	name, isBaseDigit := numberBase(l.ch)
+	l.readChar()
+	for isBaseDigit(l.ch) || l.ch == '_' {
+		l.readChar()
+	}
+	digits := strings.TrimPrefix(l.input[position+2:l.position], "_")
+
+	if !l.endsNumberAt(l.position) {
+		if isDigit(l.ch) || isLetter(l.ch) {
+			return l.illegalNumber(position, fmt.Sprintf("invalid digit %q in %s literal", l.ch, name))
+		}
+		return l.illegalNumber(position, fmt.Sprintf("invalid character %q in %s literal", l.ch, name))
+	}
+	if digits == "" {
+		return l.illegalNumber(position, fmt.Sprintf("missing digits in %s literal", name))
+	}
+	if !validSeparators(digits) {
+		return l.illegalNumber(position, fmt.Sprintf("misplaced digit separator `_` in %s literal", name))
+	}
+	return newToken(token.INT, l.line, l.input[position:l.position], l.position)
+}
+
+func (l *Lexer) illegalNumber(position int, reason string) token.Token {
+	for isLetter(l.ch) || isDigit(l.ch) || l.ch == '.' {
+		l.readChar()
 	}
+	literal := l.input[position:l.position]
+	l.errorf("%s %s", reason, literal)
+	return newToken(token.ILLEGAL, l.line, literal, l.position)
 }
 
-func (l *Lexer) readUntilWhitespace() string {
+func (l *Lexer) errorf(format string, args ...interface{}) {
+	msg := fmt.Sprintf("File: %s: Line %d: %s", file.GetFileName(), l.line+1, fmt.Sprintf(format, args...))
+	l.errors = append(l.errors, msg)
+}
+
+func (l *Lexer) isExponentStart() bool {
+	next := l.peekChar()
+	if isDigit(next) {
+		return true
+	}
+	if (next == '+' || next == '-') && l.readPosition+1 < len(l.input) {
+		return isDigit(l.input[l.readPosition+1])
+	}
+	return false
+}
+
+func (l *Lexer) readExponent() string {
 	position := l.position
-	for !isWhitespace(l.ch) {
+	l.readChar()
+	if l.ch == '+' || l.ch == '-' {
 		l.readChar()
 	}
-	return string(l.input[position:l.position])
+	l.readNumber()
+	return l.input[position:l.position]
+}
+
+func (l *Lexer) charAt(position int) byte {
+	if position >= len(l.input) {
+		return 0
+	}
+	return l.input[position]
+}
+
+func (l *Lexer) endsNumberAt(position int) bool {
+	ch := l.charAt(position)
+	next := l.charAt(position + 1)
+	return isNumberTerminator(ch) || (ch == '.' && (isLetter(next) || next == '.'))
+}
+
+func isBasePrefix(ch byte) bool {
+	return ch == 'x' || ch == 'X' || ch == 'o' || ch == 'O' || ch == 'b' || ch == 'B'
+}
+
+func numberBase(prefix byte) (string, func(byte) bool) {
+	switch prefix {
+	case 'x', 'X':
+		return "hex", isHexDigit
+	case 'o', 'O':
+		return "octal", func(ch byte) bool { return '0' <= ch && ch <= '7' }
+	default:
+		return "binary", func(ch byte) bool { return ch == '0' || ch == '1' }
+	}
+}
+
+func validSeparators(digits string) bool {
+	return !strings.HasPrefix(digits, "_") && !strings.HasSuffix(digits, "_") && !strings.Contains(digits, "__")
+}
+
+func isHexDigit(ch byte) bool {
+	return isDigit(ch) || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+func isNumberTerminator(ch byte) bool {
+	return isEmpty(ch) || isWhitespace(ch) || isOperator(ch) || isComparison(ch) || isCompound(ch) || isBracket(ch) || isBrace(ch) || isParen(ch) || isBitwise(ch)
+}
+
+func isBitwise(ch byte) bool {
+	return ch == byte('^') || ch == byte('&') || ch == byte('|') || ch == byte('~')
 }
@@ -0,0 +1,264 @@
+package lexer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+type expectedToken struct {
+	tokType token.TokenType
+	literal string
+}
+
+func testTokens(t *testing.T, input string, expected []expectedToken) {
+	t.Helper()
+	l := New(input)
+	for i, want := range expected {
+		tok := l.NextToken()
+		if tok.Type != want.tokType || tok.Literal != want.literal {
+			t.Errorf("%q: token %d: got %s %q, want %s %q", input, i, tok.Type, tok.Literal, want.tokType, want.literal)
+		}
+	}
+	if errs := l.Errors(); len(errs) != 0 {
+		t.Errorf("%q: unexpected lexer errors: %v", input, errs)
+	}
+}
+
+func TestNumericLiterals(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []expectedToken
+	}{
+		{"0xFF", []expectedToken{{token.INT, "0xFF"}, {token.EOF, "\x00"}}},
+		{"0Xff", []expectedToken{{token.INT, "0Xff"}}},
+		{"0o755", []expectedToken{{token.INT, "0o755"}}},
+		{"0b1010", []expectedToken{{token.INT, "0b1010"}}},
+		{"0x_FF_FF", []expectedToken{{token.INT, "0x_FF_FF"}}},
+		{"1_000_000", []expectedToken{{token.INT, "1_000_000"}}},
+		{"1.5", []expectedToken{{token.FLOAT, "1.5"}}},
+		{"6.02e23", []expectedToken{{token.FLOAT, "6.02e23"}}},
+		{"1e-9", []expectedToken{{token.FLOAT, "1e-9"}}},
+		{"2E+3", []expectedToken{{token.FLOAT, "2E+3"}}},
+		{"1_000.5e1_0", []expectedToken{{token.FLOAT, "1_000.5e1_0"}}},
+		{"0xFF+1", []expectedToken{{token.INT, "0xFF"}, {token.PLUS, "+"}, {token.INT, "1"}}},
+		{"2^10", []expectedToken{{token.INT, "2"}, {token.CARET, "^"}, {token.INT, "10"}}},
+		{"f(0b1)", []expectedToken{{token.IDENTIFIER, "f"}, {token.LPAREN, "("}, {token.INT, "0b1"}, {token.RPAREN, ")"}}},
+		{"1.e", []expectedToken{{token.INT, "1"}, {token.DOT, "."}, {token.IDENTIFIER, "e"}}},
+		{"12.50d", []expectedToken{{token.DECIMAL, "12.50d"}}},
+		{"3d*2", []expectedToken{{token.DECIMAL, "3d"}, {token.ASTERISK, "*"}, {token.INT, "2"}}},
+		{"1.5e2d", []expectedToken{{token.DECIMAL, "1.5e2d"}}},
+		{"5.abs()", []expectedToken{{token.INT, "5"}, {token.DOT, "."}, {token.IDENTIFIER, "abs"}, {token.LPAREN, "("}, {token.RPAREN, ")"}}},
+		{"1.5.round(2)", []expectedToken{{token.FLOAT, "1.5"}, {token.DOT, "."}, {token.IDENTIFIER, "round"}}},
+		{"x.d", []expectedToken{{token.IDENTIFIER, "x"}, {token.DOT, "."}, {token.IDENTIFIER, "d"}}},
+	}
+
+	for _, tt := range tests {
+		testTokens(t, tt.input, tt.expected)
+	}
+}
+
+func TestInvalidNumericLiterals(t *testing.T) {
+	tests := []struct {
+		input   string
+		literal string
+		err     string
+	}{
+		{"0b102", "0b102", "invalid digit '2' in binary literal 0b102"},
+		{"0o9", "0o9", "invalid digit '9' in octal literal 0o9"},
+		{"0xFG", "0xFG", "invalid digit 'G' in hex literal 0xFG"},
+		{"0x", "0x", "missing digits in hex literal 0x"},
+		{"0b_", "0b_", "missing digits in binary literal 0b_"},
+		{"12abc", "12abc", "invalid character 'a' in number literal 12abc"},
+		{"1.2.3", "1.2.3", "invalid character '.' in number literal 1.2.3"},
+		{"1__0", "1__0", "misplaced digit separator `_` in number literal 1__0"},
+		{"1_", "1_", "misplaced digit separator `_` in number literal 1_"},
+		{"1.5_", "1.5_", "misplaced digit separator `_` in number literal 1.5_"},
+		{"0xF__F", "0xF__F", "misplaced digit separator `_` in hex literal 0xF__F"},
+	}
+
+	for _, tt := range tests {
+		l := New(tt.input)
+		tok := l.NextToken()
+		if tok.Type != token.ILLEGAL || tok.Literal != tt.literal {
+			t.Errorf("%q: got %s %q, want ILLEGAL %q", tt.input, tok.Type, tok.Literal, tt.literal)
+		}
+		errs := l.Errors()
+		if len(errs) != 1 || !strings.HasSuffix(errs[0], "Line 1: "+tt.err) {
+			t.Errorf("%q: got errors %v, want %q", tt.input, errs, tt.err)
+		}
+	}
+}
+
+func TestInvalidNumberStopsAtDelimiter(t *testing.T) {
+	l := New("f(0b12)\nx")
+	for _, want := range []token.TokenType{token.IDENTIFIER, token.LPAREN, token.ILLEGAL, token.RPAREN, token.IDENTIFIER, token.EOF} {
+		if tok := l.NextToken(); tok.Type != want {
+			t.Fatalf("got %s %q, want %s", tok.Type, tok.Literal, want)
+		}
+	}
+}
+
+func TestBitwiseOperators(t *testing.T) {
+	testTokens(t, "a & b | c ~ ~d << 2 >> 1 && e || f", []expectedToken{
+		{token.IDENTIFIER, "a"},
+		{token.BIT_AND, "&"},
+		{token.IDENTIFIER, "b"},
+		{token.BIT_OR, "|"},
+		{token.IDENTIFIER, "c"},
+		{token.TILDE, "~"},
+		{token.TILDE, "~"},
+		{token.IDENTIFIER, "d"},
+		{token.SHIFT_LEFT, "<<"},
+		{token.INT, "2"},
+		{token.SHIFT_RIGHT, ">>"},
+		{token.INT, "1"},
+		{token.AND, "&&"},
+		{token.IDENTIFIER, "e"},
+		{token.OR, "||"},
+		{token.IDENTIFIER, "f"},
+	})
+	testTokens(t, "1<=2>=3", []expectedToken{
+		{token.INT, "1"}, {token.LT_EQ, "<="}, {token.INT, "2"}, {token.GT_EQ, ">="}, {token.INT, "3"},
+	})
+}
+
+func TestRangeOperators(t *testing.T) {
+	testTokens(t, "0..5 1..=n (a..b).step(2)", []expectedToken{
+		{token.INT, "0"},
+		{token.DOT_DOT, ".."},
+		{token.INT, "5"},
+		{token.INT, "1"},
+		{token.DOT_DOT_EQ, "..="},
+		{token.IDENTIFIER, "n"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "a"},
+		{token.DOT_DOT, ".."},
+		{token.IDENTIFIER, "b"},
+		{token.RPAREN, ")"},
+		{token.DOT, "."},
+		{token.IDENTIFIER, "step"},
+		{token.LPAREN, "("},
+		{token.INT, "2"},
+		{token.RPAREN, ")"},
+	})
+}
+
+func TestYieldKeyword(t *testing.T) {
+	testTokens(t, "yield x; yielded", []expectedToken{
+		{token.YIELD, "yield"},
+		{token.IDENTIFIER, "x"},
+		{token.SEMICOLON, ";"},
+		{token.IDENTIFIER, "yielded"},
+	})
+}
+
+func TestDestructuringTokens(t *testing.T) {
+	testTokens(t, "var [a, ...rest] = xs; var {age: years} = p", []expectedToken{
+		{token.VAR, "var"},
+		{token.LBRACKET, "["},
+		{token.IDENTIFIER, "a"},
+		{token.COMMA, ","},
+		{token.ELLIPSIS, "..."},
+		{token.IDENTIFIER, "rest"},
+		{token.RBRACKET, "]"},
+		{token.ASSIGN, "="},
+		{token.IDENTIFIER, "xs"},
+		{token.SEMICOLON, ";"},
+		{token.VAR, "var"},
+		{token.LBRACE, "{"},
+		{token.IDENTIFIER, "age"},
+		{token.COLON, ":"},
+		{token.IDENTIFIER, "years"},
+		{token.RBRACE, "}"},
+		{token.ASSIGN, "="},
+		{token.IDENTIFIER, "p"},
+	})
+}
+
+func TestArrowTokens(t *testing.T) {
+	testTokens(t, "(a, b) => a >= b == c", []expectedToken{
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "a"},
+		{token.COMMA, ","},
+		{token.IDENTIFIER, "b"},
+		{token.RPAREN, ")"},
+		{token.ARROW, "=>"},
+		{token.IDENTIFIER, "a"},
+		{token.GT_EQ, ">="},
+		{token.IDENTIFIER, "b"},
+		{token.EQ, "=="},
+		{token.IDENTIFIER, "c"},
+	})
+}
+
+func TestPipelineToken(t *testing.T) {
+	testTokens(t, "xs |> f(_) | g", []expectedToken{
+		{token.IDENTIFIER, "xs"},
+		{token.PIPE, "|>"},
+		{token.IDENTIFIER, "f"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "_"},
+		{token.RPAREN, ")"},
+		{token.BIT_OR, "|"},
+		{token.IDENTIFIER, "g"},
+	})
+}
+
+func TestOptionalChainTokens(t *testing.T) {
+	testTokens(t, "a?.b ?? c ? d : e?.[0]", []expectedToken{
+		{token.IDENTIFIER, "a"},
+		{token.OPTIONAL_DOT, "?."},
+		{token.IDENTIFIER, "b"},
+		{token.NULLISH, "??"},
+		{token.IDENTIFIER, "c"},
+		{token.QUESTION, "?"},
+		{token.IDENTIFIER, "d"},
+		{token.COLON, ":"},
+		{token.IDENTIFIER, "e"},
+		{token.OPTIONAL_DOT, "?."},
+		{token.LBRACKET, "["},
+		{token.INT, "0"},
+		{token.RBRACKET, "]"},
+	})
+}
+
+func TestMatchTokens(t *testing.T) {
+	testTokens(t, "match (x) { n: INTEGER if n > 0 => n, _ => 0 }", []expectedToken{
+		{token.MATCH, "match"},
+		{token.LPAREN, "("},
+		{token.IDENTIFIER, "x"},
+		{token.RPAREN, ")"},
+		{token.LBRACE, "{"},
+		{token.IDENTIFIER, "n"},
+		{token.COLON, ":"},
+		{token.IDENTIFIER, "INTEGER"},
+		{token.IF, "if"},
+		{token.IDENTIFIER, "n"},
+		{token.GT, ">"},
+		{token.INT, "0"},
+		{token.ARROW, "=>"},
+		{token.IDENTIFIER, "n"},
+		{token.COMMA, ","},
+		{token.IDENTIFIER, "_"},
+		{token.ARROW, "=>"},
+		{token.INT, "0"},
+		{token.RBRACE, "}"},
+	})
+}
+
+func TestConstKeyword(t *testing.T) {
+	testTokens(t, "const limit = freeze([1]);", []expectedToken{
+		{token.CONST, "const"},
+		{token.IDENTIFIER, "limit"},
+		{token.ASSIGN, "="},
+		{token.IDENTIFIER, "freeze"},
+		{token.LPAREN, "("},
+		{token.LBRACKET, "["},
+		{token.INT, "1"},
+		{token.RBRACKET, "]"},
+		{token.RPAREN, ")"},
+		{token.SEMICOLON, ";"},
+	})
+}
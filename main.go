@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/evaluator"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/file"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/lexer"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/parser"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/repl"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/resolver"
 )
 
 func main() {
@@ -39,7 +41,14 @@ func main() {
 		}
 
 		evaluator.DefineMacros(program, macroEnv)
-		expanded := evaluator.ExpandMacros(program, macroEnv)
+		expanded := evaluator.ExpandMacros(program, macroEnv).(*ast.Program)
+
+		r := resolver.New()
+		r.Resolve(expanded)
+		if len(r.Errors()) != 0 {
+			evaluator.PrintParserErrors(os.Stdout, r.Errors())
+			return
+		}
 
 		evaluator.Eval(expanded, env)
 	}
@@ -0,0 +1,331 @@
+package object
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type RoundingMode string
+
+const (
+	ROUND_HALF_EVEN RoundingMode = "half_even"
+	ROUND_HALF_UP   RoundingMode = "half_up"
+	ROUND_HALF_DOWN RoundingMode = "half_down"
+	ROUND_UP        RoundingMode = "up"
+	ROUND_DOWN      RoundingMode = "down"
+	ROUND_CEILING   RoundingMode = "ceiling"
+	ROUND_FLOOR     RoundingMode = "floor"
+)
+
+var roundingModes = map[string]RoundingMode{
+	"half_even": ROUND_HALF_EVEN,
+	"half_up":   ROUND_HALF_UP,
+	"half_down": ROUND_HALF_DOWN,
+	"up":        ROUND_UP,
+	"down":      ROUND_DOWN,
+	"ceiling":   ROUND_CEILING,
+	"floor":     ROUND_FLOOR,
+}
+
+type DecimalContext struct {
+	Scale int
+	Mode  RoundingMode
+}
+
+var DefaultDecimalContext = DecimalContext{Scale: 16, Mode: ROUND_HALF_EVEN}
+
+func LookupRoundingMode(name string) (RoundingMode, bool) {
+	mode, ok := roundingModes[name]
+	return mode, ok
+}
+
+type Decimal struct {
+	Value *big.Int
+	Scale int
+}
+
+func NewDecimalFromInt(value *big.Int) *Decimal {
+	return &Decimal{Value: value, Scale: 0}
+}
+
+func ParseDecimal(input string) (*Decimal, error) {
+	literal := strings.ReplaceAll(input, "_", "")
+	exponent := 0
+	if idx := strings.IndexAny(literal, "eE"); idx >= 0 {
+		exp, err := strconv.Atoi(literal[idx+1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent in decimal %q", input)
+		}
+		exponent = exp
+		literal = literal[:idx]
+	}
+
+	scale := 0
+	if idx := strings.Index(literal, "."); idx >= 0 {
+		scale = len(literal) - idx - 1
+		literal = literal[:idx] + literal[idx+1:]
+	}
+	if literal == "" || literal == "-" || literal == "+" {
+		return nil, fmt.Errorf("invalid decimal %q", input)
+	}
+
+	value, ok := new(big.Int).SetString(literal, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", input)
+	}
+
+	scale -= exponent
+	if scale < 0 {
+		value.Mul(value, pow10(-scale))
+		scale = 0
+	}
+	return &Decimal{Value: value, Scale: scale}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func roundQuotient(numerator, denominator *big.Int, mode RoundingMode) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(numerator, denominator, new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	sign := numerator.Sign() * denominator.Sign()
+	awayFromZero := false
+	switch mode {
+	case ROUND_UP:
+		awayFromZero = true
+	case ROUND_DOWN:
+		awayFromZero = false
+	case ROUND_CEILING:
+		awayFromZero = sign > 0
+	case ROUND_FLOOR:
+		awayFromZero = sign < 0
+	default:
+		twiceRemainder := new(big.Int).Abs(remainder)
+		twiceRemainder.Lsh(twiceRemainder, 1)
+		switch twiceRemainder.Cmp(new(big.Int).Abs(denominator)) {
+		case 1:
+			awayFromZero = true
+		case 0:
+			awayFromZero = mode == ROUND_HALF_UP || (mode == ROUND_HALF_EVEN && quotient.Bit(0) == 1)
+		}
+	}
+
+	if awayFromZero {
+		quotient.Add(quotient, big.NewInt(int64(sign)))
+	}
+	return quotient
+}
+
+func (d *Decimal) Rescale(scale int, mode RoundingMode) *Decimal {
+	if scale >= d.Scale {
+		value := new(big.Int).Mul(d.Value, pow10(scale-d.Scale))
+		return &Decimal{Value: value, Scale: scale}
+	}
+	value := roundQuotient(d.Value, pow10(d.Scale-scale), mode)
+	return &Decimal{Value: value, Scale: scale}
+}
+
+func (d *Decimal) trimTo(minScale int) *Decimal {
+	value := new(big.Int).Set(d.Value)
+	scale := d.Scale
+	ten := big.NewInt(10)
+	remainder := new(big.Int)
+	for scale > minScale {
+		quotient, rem := new(big.Int).QuoRem(value, ten, remainder)
+		if rem.Sign() != 0 {
+			break
+		}
+		value = quotient
+		scale--
+	}
+	return &Decimal{Value: value, Scale: scale}
+}
+
+func alignDecimals(a, b *Decimal) (*big.Int, *big.Int, int) {
+	if a.Scale == b.Scale {
+		return a.Value, b.Value, a.Scale
+	}
+	if a.Scale > b.Scale {
+		return a.Value, b.Rescale(a.Scale, ROUND_DOWN).Value, a.Scale
+	}
+	return a.Rescale(b.Scale, ROUND_DOWN).Value, b.Value, b.Scale
+}
+
+func (d *Decimal) Add(other *Decimal) *Decimal {
+	a, b, scale := alignDecimals(d, other)
+	return &Decimal{Value: new(big.Int).Add(a, b), Scale: scale}
+}
+
+func (d *Decimal) Sub(other *Decimal) *Decimal {
+	a, b, scale := alignDecimals(d, other)
+	return &Decimal{Value: new(big.Int).Sub(a, b), Scale: scale}
+}
+
+func (d *Decimal) Mul(other *Decimal) *Decimal {
+	return &Decimal{Value: new(big.Int).Mul(d.Value, other.Value), Scale: d.Scale + other.Scale}
+}
+
+func (d *Decimal) Quo(other *Decimal, scale int, mode RoundingMode) *Decimal {
+	numerator := new(big.Int).Set(d.Value)
+	denominator := new(big.Int).Set(other.Value)
+	shift := scale + other.Scale - d.Scale
+	if shift >= 0 {
+		numerator.Mul(numerator, pow10(shift))
+	} else {
+		denominator.Mul(denominator, pow10(-shift))
+	}
+	return &Decimal{Value: roundQuotient(numerator, denominator, mode), Scale: scale}
+}
+
+func (d *Decimal) Div(other *Decimal, context DecimalContext) *Decimal {
+	minScale := d.Scale
+	if other.Scale > minScale {
+		minScale = other.Scale
+	}
+	scale := context.Scale
+	if minScale > scale {
+		scale = minScale
+	}
+	return d.Quo(other, scale, context.Mode).trimTo(minScale)
+}
+
+func (d *Decimal) Pow(exponent int64, context DecimalContext) *Decimal {
+	value := new(big.Int).Exp(d.Value, big.NewInt(exponent), nil)
+	power := &Decimal{Value: value, Scale: d.Scale * int(exponent)}
+	if power.Scale <= context.Scale {
+		return power
+	}
+	scale := context.Scale
+	if d.Scale > scale {
+		scale = d.Scale
+	}
+	return power.Rescale(scale, context.Mode).trimTo(d.Scale)
+}
+
+func (d *Decimal) Rem(other *Decimal) *Decimal {
+	a, b, scale := alignDecimals(d, other)
+	return &Decimal{Value: new(big.Int).Rem(a, b), Scale: scale}
+}
+
+func (d *Decimal) Neg() *Decimal {
+	return &Decimal{Value: new(big.Int).Neg(d.Value), Scale: d.Scale}
+}
+
+func (d *Decimal) Cmp(other *Decimal) int {
+	a, b, _ := alignDecimals(d, other)
+	return a.Cmp(b)
+}
+
+func (d *Decimal) Sign() int { return d.Value.Sign() }
+
+func (d *Decimal) Truncate() *big.Int {
+	return new(big.Int).Quo(d.Value, pow10(d.Scale))
+}
+
+func (d *Decimal) Type() ObjectType { return DECIMAL_OBJ }
+func (d *Decimal) Inspect() string {
+	digits := new(big.Int).Abs(d.Value).String()
+	if d.Scale > 0 {
+		if len(digits) <= d.Scale {
+			digits = strings.Repeat("0", d.Scale-len(digits)+1) + digits
+		}
+		point := len(digits) - d.Scale
+		digits = digits[:point] + "." + digits[point:]
+	}
+	if d.Value.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+func (d *Decimal) HashKey() HashKey {
+	trimmed := d.trimTo(0)
+	if trimmed.Scale == 0 {
+		if trimmed.Value.IsInt64() {
+			return (&Integer{Value: trimmed.Value.Int64()}).HashKey()
+		}
+		return (&BigInteger{Value: trimmed.Value}).HashKey()
+	}
+	h := fnv.New64a()
+	h.Write([]byte(trimmed.Inspect()))
+	return HashKey{Type: d.Type(), Value: h.Sum64()}
+}
+func (d *Decimal) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	switch method {
+	case "round":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to round()!"}
+		}
+		scale, mode, err := decimalScaleAndMode("round", args)
+		if err != nil {
+			return err
+		}
+		return d.Rescale(scale, mode)
+	case "div":
+		if len(args) < 2 {
+			return &Error{Message: "Missing arguments to div()!"}
+		}
+		var divisor *Decimal
+		switch arg := args[0].(type) {
+		case *Decimal:
+			divisor = arg
+		case *Integer:
+			divisor = NewDecimalFromInt(big.NewInt(arg.Value))
+		case *BigInteger:
+			divisor = NewDecimalFromInt(arg.Value)
+		default:
+			return &Error{Message: "First argument to div() must be a decimal or an integer!"}
+		}
+		if divisor.Sign() == 0 {
+			return &Error{Message: "Division by zero in div()!"}
+		}
+		scale, mode, err := decimalScaleAndMode("div", args[1:])
+		if err != nil {
+			return err
+		}
+		return d.Quo(divisor, scale, mode)
+	case "scale":
+		return &Integer{Value: int64(d.Scale)}
+	case "abs":
+		return &Decimal{Value: new(big.Int).Abs(d.Value), Scale: d.Scale}
+	case "floor":
+		return d.Rescale(0, ROUND_FLOOR)
+	case "ceil":
+		return d.Rescale(0, ROUND_CEILING)
+	case "toFixed":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to toFixed()!"}
+		}
+		scale, mode, err := decimalScaleAndMode("toFixed", args)
+		if err != nil {
+			return err
+		}
+		return &String{Value: d.Rescale(scale, mode).Inspect()}
+	default:
+		return nil
+	}
+}
+
+func decimalScaleAndMode(method string, args []Object) (int, RoundingMode, *Error) {
+	scale, ok := args[0].(*Integer)
+	if !ok || scale.Value < 0 {
+		return 0, "", &Error{Message: fmt.Sprintf("Scale given to %s() must be a non-negative integer!", method)}
+	}
+	mode := DefaultDecimalContext.Mode
+	if len(args) >= 2 {
+		name, ok := args[1].(*String)
+		if !ok {
+			return 0, "", &Error{Message: fmt.Sprintf("Rounding mode given to %s() must be a string!", method)}
+		}
+		if mode, ok = LookupRoundingMode(name.Value); !ok {
+			return 0, "", &Error{Message: fmt.Sprintf("Invalid rounding mode for %s()! Mode given %s", method, name.Value)}
+		}
+	}
+	return int(scale.Value), mode, nil
+}
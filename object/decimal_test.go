@@ -0,0 +1,104 @@
+package object
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		scale    int
+	}{
+		{"12.50", "12.50", 2},
+		{"0.1", "0.1", 1},
+		{"-3.05", "-3.05", 2},
+		{"1_000.5", "1000.5", 1},
+		{"1.5e2", "150", 0},
+		{"1.25e-3", "0.00125", 5},
+		{"7", "7", 0},
+	}
+	for _, tt := range tests {
+		d, err := ParseDecimal(tt.input)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", tt.input, err)
+			continue
+		}
+		if d.Inspect() != tt.expected || d.Scale != tt.scale {
+			t.Errorf("%q: got %s (scale %d), want %s (scale %d)", tt.input, d.Inspect(), d.Scale, tt.expected, tt.scale)
+		}
+	}
+
+	for _, input := range []string{"", "-", "abc", "1.2.3", "1e"} {
+		if _, err := ParseDecimal(input); err == nil {
+			t.Errorf("%q: expected an error", input)
+		}
+	}
+}
+
+func TestDecimalRescale(t *testing.T) {
+	tests := []struct {
+		input    string
+		mode     RoundingMode
+		expected string
+	}{
+		{"2.345", ROUND_HALF_EVEN, "2.34"},
+		{"2.355", ROUND_HALF_EVEN, "2.36"},
+		{"2.345", ROUND_HALF_UP, "2.35"},
+		{"2.345", ROUND_HALF_DOWN, "2.34"},
+		{"2.341", ROUND_UP, "2.35"},
+		{"2.349", ROUND_DOWN, "2.34"},
+		{"-2.341", ROUND_CEILING, "-2.34"},
+		{"-2.341", ROUND_FLOOR, "-2.35"},
+		{"2.3", ROUND_HALF_EVEN, "2.30"},
+	}
+	for _, tt := range tests {
+		d, _ := ParseDecimal(tt.input)
+		if got := d.Rescale(2, tt.mode).Inspect(); got != tt.expected {
+			t.Errorf("%s rounded %s: got %s, want %s", tt.input, tt.mode, got, tt.expected)
+		}
+	}
+}
+
+func TestDecimalDivUsesContext(t *testing.T) {
+	one, _ := ParseDecimal("1")
+	three, _ := ParseDecimal("3")
+	tests := []struct {
+		context  DecimalContext
+		expected string
+	}{
+		{DefaultDecimalContext, "0.3333333333333333"},
+		{DecimalContext{Scale: 2, Mode: ROUND_UP}, "0.34"},
+		{DecimalContext{Scale: 0, Mode: ROUND_HALF_EVEN}, "0"},
+	}
+	for _, tt := range tests {
+		if got := one.Div(three, tt.context).Inspect(); got != tt.expected {
+			t.Errorf("1 / 3 with %+v: got %s, want %s", tt.context, got, tt.expected)
+		}
+	}
+}
+
+func TestDecimalHashKeyMatchesIntegers(t *testing.T) {
+	tests := []struct {
+		decimal string
+		integer Hashable
+	}{
+		{"1", &Integer{Value: 1}},
+		{"1.000", &Integer{Value: 1}},
+		{"-42.0", &Integer{Value: -42}},
+		{"18446744073709551616", &BigInteger{Value: new(big.Int).Lsh(big.NewInt(1), 64)}},
+	}
+	for _, tt := range tests {
+		d, _ := ParseDecimal(tt.decimal)
+		if d.HashKey() != tt.integer.HashKey() {
+			t.Errorf("%s: hash key %v differs from integer key %v", tt.decimal, d.HashKey(), tt.integer.HashKey())
+		}
+	}
+
+	a, _ := ParseDecimal("2.5")
+	b, _ := ParseDecimal("2.50")
+	if a.HashKey() != b.HashKey() {
+		t.Errorf("2.5 and 2.50 have different hash keys")
+	}
+}
@@ -0,0 +1,155 @@
+package object
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"strings"
+)
+
+var enumCount uint64
+
+type Enum struct {
+	Name     string
+	Variants []*EnumVariant
+
+	id uint64
+}
+
+type EnumVariant struct {
+	Enum   *Enum
+	Name   string
+	Fields []string
+	Index  int
+
+	unit *EnumValue
+}
+
+type EnumValue struct {
+	Variant *EnumVariant
+	Values  []Object
+}
+
+func NewEnum(name string) *Enum {
+	enumCount++
+	return &Enum{Name: name, id: enumCount}
+}
+
+func (e *Enum) AddVariant(name string, fields []string) *EnumVariant {
+	variant := &EnumVariant{Enum: e, Name: name, Fields: fields, Index: len(e.Variants)}
+	if len(fields) == 0 {
+		variant.unit = &EnumValue{Variant: variant}
+	}
+	e.Variants = append(e.Variants, variant)
+	return variant
+}
+
+func (e *Enum) Variant(name string) (*EnumVariant, bool) {
+	for _, variant := range e.Variants {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+	return nil, false
+}
+
+func (e *Enum) Type() ObjectType { return ENUM_OBJ }
+func (e *Enum) Inspect() string {
+	variants := []string{}
+	for _, variant := range e.Variants {
+		variants = append(variants, variant.signature())
+	}
+	return "enum " + e.Name + " { " + strings.Join(variants, ", ") + " }"
+}
+func (e *Enum) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	variant, ok := e.Variant(method)
+	if !ok {
+		return nil
+	}
+	return variant.Construct(args)
+}
+
+func (v *EnumVariant) String() string { return v.Enum.Name + "." + v.Name }
+
+func (v *EnumVariant) signature() string {
+	if len(v.Fields) == 0 {
+		return v.Name
+	}
+	return v.Name + "(" + strings.Join(v.Fields, ", ") + ")"
+}
+
+func (v *EnumVariant) Unit() (*EnumValue, bool) {
+	return v.unit, v.unit != nil
+}
+
+func (v *EnumVariant) Construct(args []Object) Object {
+	if v.unit != nil {
+		return &Error{Message: fmt.Sprintf("%s has no payload, use it without parentheses!", v)}
+	}
+	if len(args) != len(v.Fields) {
+		return &Error{Message: fmt.Sprintf("Wrong number of arguments to %s()! got=%d, want=%d", v, len(args), len(v.Fields))}
+	}
+	values := make([]Object, len(args))
+	copy(values, args)
+	return &EnumValue{Variant: v, Values: values}
+}
+
+func (ev *EnumValue) Field(name string) (Object, bool) {
+	for i, field := range ev.Variant.Fields {
+		if field == name {
+			return ev.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+func (ev *EnumValue) Type() ObjectType { return ENUM_VALUE_OBJ }
+func (ev *EnumValue) Inspect() string {
+	if len(ev.Values) == 0 {
+		return ev.Variant.String()
+	}
+	values := []string{}
+	for _, value := range ev.Values {
+		values = append(values, value.Inspect())
+	}
+	return ev.Variant.String() + "(" + strings.Join(values, ", ") + ")"
+}
+func (ev *EnumValue) HashKey() HashKey {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], ev.Variant.Enum.id)
+	h.Write(buf[:])
+	binary.LittleEndian.PutUint64(buf[:], uint64(ev.Variant.Index))
+	h.Write(buf[:])
+	for _, value := range ev.Values {
+		h.Write([]byte(value.Type()))
+		if hashable, ok := value.(Hashable); ok {
+			binary.LittleEndian.PutUint64(buf[:], hashable.HashKey().Value)
+			h.Write(buf[:])
+		} else {
+			h.Write([]byte(value.Inspect()))
+		}
+	}
+	return HashKey{Type: ev.Type(), Value: h.Sum64()}
+}
+func (ev *EnumValue) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	switch method {
+	case "variant":
+		return &String{Value: ev.Variant.Name}
+	case "values":
+		values := make([]Object, len(ev.Values))
+		copy(values, ev.Values)
+		return &Array{Elements: values}
+	case "is":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to is()!"}
+		}
+		name, ok := args[0].(*String)
+		if !ok {
+			return &Error{Message: "First argument to is() must be a string!"}
+		}
+		return &Boolean{Value: ev.Variant.Name == name.Value}
+	default:
+		return nil
+	}
+}
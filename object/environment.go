@@ -1,9 +1,7 @@
 package object
 
 func NewEnclosedEnvironment(outer *Environment) *Environment {
-	env := NewEnvironment()
-	env.outer = outer
-	return env
+	return &Environment{outer: outer}
 }
 
 func NewEnvironment() *Environment {
@@ -12,8 +10,13 @@ func NewEnvironment() *Environment {
 }
 
 type Environment struct {
-	store map[string]Object
-	outer *Environment
+	store     map[string]Object
+	slots     []Object
+	constants map[string]bool
+	constSlot map[int]bool
+	outer     *Environment
+	generator *Coroutine
+	decimal   *DecimalContext
 }
 
 func (e *Environment) Get(name string) (Object, bool) {
@@ -25,6 +28,105 @@ func (e *Environment) Get(name string) (Object, bool) {
 }
 
 func (e *Environment) Set(name string, val Object) Object {
+	if e.store == nil {
+		e.store = make(map[string]Object)
+	}
 	e.store[name] = val
 	return val
 }
+
+// at walks depth environments out, returning nil if the chain is shorter
+// than the resolver expected.
+func (e *Environment) at(depth int) *Environment {
+	env := e
+	for ; depth > 0 && env != nil; depth-- {
+		env = env.outer
+	}
+	return env
+}
+
+func (e *Environment) GetAt(depth, slot int) (Object, bool) {
+	env := e.at(depth)
+	if env == nil || slot >= len(env.slots) || env.slots[slot] == nil {
+		return nil, false
+	}
+	return env.slots[slot], true
+}
+
+func (e *Environment) SetAt(slot int, val Object) Object {
+	if slot >= len(e.slots) {
+		slots := make([]Object, slot+1)
+		copy(slots, e.slots)
+		e.slots = slots
+	}
+	e.slots[slot] = val
+	return val
+}
+
+func (e *Environment) AssignAt(depth, slot int, val Object) (Object, bool) {
+	if _, ok := e.GetAt(depth, slot); !ok {
+		return nil, false
+	}
+	return e.at(depth).SetAt(slot, val), true
+}
+
+func (e *Environment) Assign(name string, val Object) (Object, bool) {
+	if _, ok := e.store[name]; ok {
+		return e.Set(name, val), true
+	}
+	if e.outer != nil {
+		return e.outer.Assign(name, val)
+	}
+	return nil, false
+}
+
+func (e *Environment) SetConst(name string, val Object) Object {
+	if e.constants == nil {
+		e.constants = make(map[string]bool)
+	}
+	e.constants[name] = true
+	return e.Set(name, val)
+}
+
+func (e *Environment) SetConstAt(slot int, val Object) Object {
+	if e.constSlot == nil {
+		e.constSlot = make(map[int]bool)
+	}
+	e.constSlot[slot] = true
+	return e.SetAt(slot, val)
+}
+
+func (e *Environment) IsConstAt(depth, slot int) bool {
+	env := e.at(depth)
+	return env != nil && env.constSlot[slot]
+}
+
+func (e *Environment) IsConst(name string) bool {
+	if _, ok := e.store[name]; ok {
+		return e.constants[name]
+	}
+	if e.outer != nil {
+		return e.outer.IsConst(name)
+	}
+	return false
+}
+
+func (e *Environment) DecimalContext() DecimalContext {
+	for env := e; env != nil; env = env.outer {
+		if env.decimal != nil {
+			return *env.decimal
+		}
+	}
+	return DefaultDecimalContext
+}
+
+func (e *Environment) SetDecimalContext(context DecimalContext) {
+	e.decimal = &context
+}
+
+func (e *Environment) Generator() (*Coroutine, bool) {
+	if e.generator == nil && e.outer != nil {
+		return e.outer.Generator()
+	}
+	return e.generator, e.generator != nil
+}
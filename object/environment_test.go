@@ -0,0 +1,81 @@
+package object
+
+import "testing"
+
+func TestAssignUpdatesDefiningScope(t *testing.T) {
+	global := NewEnvironment()
+	global.Set("count", &Integer{Value: 1})
+	function := NewEnclosedEnvironment(NewEnclosedEnvironment(global))
+
+	tests := []struct {
+		env  *Environment
+		name string
+		ok   bool
+	}{
+		{function, "count", true},
+		{global, "count", true},
+		{function, "missing", false},
+	}
+	for i, tt := range tests {
+		value := &Integer{Value: int64(i + 10)}
+		if _, ok := tt.env.Assign(tt.name, value); ok != tt.ok {
+			t.Fatalf("Assign(%q) ok = %t, want %t", tt.name, ok, tt.ok)
+		}
+		if !tt.ok {
+			if _, ok := global.Get(tt.name); ok {
+				t.Errorf("Assign(%q) created a binding", tt.name)
+			}
+			continue
+		}
+		if got, _ := global.Get(tt.name); got != value {
+			t.Errorf("Assign(%q) left the defining scope at %s", tt.name, got.Inspect())
+		}
+		if _, ok := function.store[tt.name]; ok {
+			t.Errorf("Assign(%q) shadowed the binding in the inner scope", tt.name)
+		}
+	}
+}
+
+func TestSlotLookupsPastTheOutermostScope(t *testing.T) {
+	global := NewEnvironment()
+	global.SetAt(0, &Integer{Value: 1})
+	inner := NewEnclosedEnvironment(global)
+
+	tests := []struct {
+		depth int
+		slot  int
+		ok    bool
+	}{
+		{1, 0, true},
+		{0, 0, false},
+		{1, 3, false},
+		{2, 0, false},
+		{5, 0, false},
+	}
+	for _, tt := range tests {
+		if _, ok := inner.GetAt(tt.depth, tt.slot); ok != tt.ok {
+			t.Errorf("GetAt(%d, %d) ok = %t, want %t", tt.depth, tt.slot, ok, tt.ok)
+		}
+		if _, ok := inner.AssignAt(tt.depth, tt.slot, &Integer{Value: 2}); ok != tt.ok {
+			t.Errorf("AssignAt(%d, %d) ok = %t, want %t", tt.depth, tt.slot, ok, tt.ok)
+		}
+		if inner.IsConstAt(tt.depth, tt.slot) {
+			t.Errorf("IsConstAt(%d, %d) = true, want false", tt.depth, tt.slot)
+		}
+	}
+}
+
+func TestConstSlots(t *testing.T) {
+	global := NewEnvironment()
+	global.SetAt(0, &Integer{Value: 1})
+	global.SetConstAt(1, &Integer{Value: 2})
+	inner := NewEnclosedEnvironment(global)
+
+	if inner.IsConstAt(1, 0) || !inner.IsConstAt(1, 1) || inner.IsConstAt(0, 1) {
+		t.Errorf("got constness %t %t %t, want false true false",
+			inner.IsConstAt(1, 0), inner.IsConstAt(1, 1), inner.IsConstAt(0, 1))
+	}
+	if value, ok := inner.GetAt(1, 1); !ok || value.Inspect() != "2" {
+		t.Errorf("GetAt(1, 1) = %v, %t, want 2", value, ok)
+	}
+}
@@ -22,6 +22,6 @@ func (e *Error) Inspect() string {
 
 	return message
 }
-func (e *Error) InvokeMethod(method string, args ...Object) Object {
+func (e *Error) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	return nil
 }
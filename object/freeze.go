@@ -0,0 +1,45 @@
+package object
+
+import "fmt"
+
+func Freeze(obj Object) Object {
+	switch obj := obj.(type) {
+	case *Array:
+		if obj.frozen {
+			return obj
+		}
+		obj.frozen = true
+		for _, element := range obj.Elements {
+			Freeze(element)
+		}
+	case *Hash:
+		if obj.frozen {
+			return obj
+		}
+		obj.frozen = true
+		for _, pair := range obj.Pairs() {
+			Freeze(pair.Key)
+			Freeze(pair.Value)
+		}
+	case *EnumValue:
+		for _, value := range obj.Values {
+			Freeze(value)
+		}
+	}
+	return obj
+}
+
+// checkMutable guards every method that changes an array or a hash in place.
+func (ao *Array) checkMutable(method, preposition string) *Error {
+	if ao.frozen {
+		return &Error{Message: fmt.Sprintf("Cannot %s() %s a frozen array!", method, preposition)}
+	}
+	return nil
+}
+
+func (h *Hash) checkMutable(method, preposition string) *Error {
+	if h.frozen {
+		return &Error{Message: fmt.Sprintf("Cannot %s() %s a frozen hash!", method, preposition)}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package object
+
+type Generator struct {
+	Function *Function
+
+	coroutine *Coroutine
+	offset    int64
+}
+
+// Coroutine is the part of a generator shared with the goroutine running its
+// body. The body only ever runs while the caller waits on it, so a generator
+// is stopped explicitly with Close (foreach and destructuring do this on exit)
+// rather than from a finalizer racing the main goroutine.
+type Coroutine struct {
+	body    func() Object
+	yields  chan Object
+	resume  chan bool
+	started bool
+	running bool
+	closed  bool
+	done    bool
+	err     Object
+}
+
+func NewGenerator(fn *Function, env *Environment, body func() Object) *Generator {
+	co := &Coroutine{
+		body:   body,
+		yields: make(chan Object),
+		resume: make(chan bool),
+	}
+	env.generator = co
+	return &Generator{Function: fn, coroutine: co}
+}
+
+func (co *Coroutine) Yield(value Object) bool {
+	if co.closed {
+		return false
+	}
+	co.yields <- value
+	return <-co.resume
+}
+
+func (co *Coroutine) resumeBody() (Object, bool) {
+	if co.running {
+		return &Error{Message: "Generator is already running!"}, false
+	}
+	if co.done {
+		return nil, false
+	}
+	co.running = true
+	if !co.started {
+		co.started = true
+		go func() {
+			result := co.body()
+			if result != nil && result.Type() == ERROR_OBJ {
+				co.err = result
+			}
+			close(co.yields)
+		}()
+	} else {
+		co.resume <- true
+	}
+
+	value, ok := <-co.yields
+	co.running = false
+	if !ok {
+		co.done = true
+		return co.err, false
+	}
+	return value, true
+}
+
+func (co *Coroutine) Close() {
+	if co.done {
+		return
+	}
+	co.done = true
+	co.closed = true
+	if !co.started || co.running {
+		return
+	}
+	co.resume <- false
+	for range co.yields {
+	}
+}
+
+func (g *Generator) Close() { g.coroutine.Close() }
+
+func (g *Generator) Iter() Iterator { return g }
+func (g *Generator) Next() (Object, Object, bool) {
+	value, ok := g.coroutine.resumeBody()
+	if !ok {
+		return value, &Integer{Value: 0}, false
+	}
+	g.offset++
+	return value, &Integer{Value: g.offset - 1}, true
+}
+
+func (g *Generator) Type() ObjectType { return GENERATOR_OBJ }
+func (g *Generator) Inspect() string  { return "generator" }
+func (g *Generator) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	switch method {
+	case "next":
+		value, ok := g.coroutine.resumeBody()
+		if !ok {
+			if value != nil {
+				return value
+			}
+			return &Null{}
+		}
+		g.offset++
+		return value
+	case "done":
+		return &Boolean{Value: g.coroutine.done}
+	case "close":
+		g.Close()
+		return &Null{}
+	case "toArray":
+		elements := []Object{}
+		for {
+			value, ok := g.coroutine.resumeBody()
+			if !ok {
+				if value != nil {
+					return value
+				}
+				return &Array{Elements: elements}
+			}
+			g.offset++
+			elements = append(elements, value)
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package object
+
+import (
+	"testing"
+	"time"
+)
+
+func countingGenerator(stopped chan<- bool) *Generator {
+	env := NewEnvironment()
+	return NewGenerator(&Function{}, env, func() Object {
+		co, _ := env.Generator()
+		for i := int64(0); co.Yield(&Integer{Value: i}); i++ {
+		}
+		stopped <- true
+		return &Null{}
+	})
+}
+
+func TestGeneratorCloseStopsBody(t *testing.T) {
+	stopped := make(chan bool, 1)
+	g := countingGenerator(stopped)
+	if value := g.InvokeMethod("next", nil); value.Inspect() != "0" {
+		t.Fatalf("got %s, want 0", value.Inspect())
+	}
+	g.Close()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("generator body kept running after Close()")
+	}
+	if done := g.InvokeMethod("done", nil); done.Inspect() != "true" {
+		t.Errorf("got done() %s, want true", done.Inspect())
+	}
+}
+
+func TestGeneratorRejectsReentrantResume(t *testing.T) {
+	env := NewEnvironment()
+	var g *Generator
+	var inner Object
+	g = NewGenerator(&Function{}, env, func() Object {
+		inner = g.InvokeMethod("next", nil)
+		return &Null{}
+	})
+	if value := g.InvokeMethod("next", nil); value.Type() != NULL_OBJ {
+		t.Errorf("got %s, want null once the body finishes", value.Inspect())
+	}
+	if err, ok := inner.(*Error); !ok || err.Message != "Generator is already running!" {
+		t.Errorf("got %v from the re-entrant next(), want an already running error", inner)
+	}
+}
@@ -0,0 +1,277 @@
+package object
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+func NewIntegerFromBig(value *big.Int) Object {
+	if value.IsInt64() {
+		return &Integer{Value: value.Int64()}
+	}
+	return &BigInteger{Value: value}
+}
+
+func integerFromFloat(value float64, method string) Object {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return &Error{Message: fmt.Sprintf("Cannot convert %s to an integer in %s()!", strconv.FormatFloat(value, 'f', -1, 64), method)}
+	}
+	if value >= math.MinInt64 && value < math.MaxInt64 {
+		return &Integer{Value: int64(value)}
+	}
+	integer, _ := big.NewFloat(value).Int(nil)
+	return NewIntegerFromBig(integer)
+}
+
+func roundFloat(value float64, digits int) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	if digits < 0 {
+		scale := math.Pow(10, float64(-digits))
+		return math.Round(value/scale) * scale
+	}
+	decimal, err := ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return value
+	}
+	rounded, _ := strconv.ParseFloat(decimal.Rescale(digits, ROUND_HALF_UP).Inspect(), 64)
+	return rounded
+}
+
+func formatFixed(value float64, digits int) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	decimal, err := ParseDecimal(strconv.FormatFloat(value, 'f', -1, 64))
+	if err != nil {
+		return strconv.FormatFloat(value, 'f', digits, 64)
+	}
+	return decimal.Rescale(digits, ROUND_HALF_UP).Inspect()
+}
+
+func numberToFloat(obj Object) (float64, bool) {
+	switch obj := obj.(type) {
+	case *Integer:
+		return float64(obj.Value), true
+	case *BigInteger:
+		value, _ := new(big.Float).SetInt(obj.Value).Float64()
+		return value, true
+	case *Float:
+		return obj.Value, true
+	default:
+		return 0, false
+	}
+}
+
+func numberToBigInt(obj Object) (*big.Int, bool) {
+	switch obj := obj.(type) {
+	case *Integer:
+		return big.NewInt(obj.Value), true
+	case *BigInteger:
+		return obj.Value, true
+	default:
+		return nil, false
+	}
+}
+
+func digitsArgument(method string, args []Object) (int, *Error) {
+	if len(args) < 1 {
+		return 0, nil
+	}
+	digits, ok := args[0].(*Integer)
+	if !ok {
+		return 0, &Error{Message: fmt.Sprintf("First argument to %s() must be an integer!", method)}
+	}
+	return int(digits.Value), nil
+}
+
+func fixedDigitsArgument(args []Object) (int, *Error) {
+	digits, err := digitsArgument("toFixed", args)
+	if err == nil && digits < 0 {
+		return 0, &Error{Message: fmt.Sprintf("Digits given to toFixed() must not be negative! Digits given %d", digits)}
+	}
+	return digits, err
+}
+
+func baseArgument(args []Object) (int, *Error) {
+	if len(args) < 1 {
+		return 10, nil
+	}
+	base, ok := args[0].(*Integer)
+	if !ok {
+		return 0, &Error{Message: "First argument to toString() must be an integer!"}
+	}
+	if base.Value < 2 || base.Value > 36 {
+		return 0, &Error{Message: "Base given to toString() must be between 2 and 36!"}
+	}
+	return int(base.Value), nil
+}
+
+func clampInteger(value *big.Int, args []Object) Object {
+	if len(args) < 2 {
+		return &Error{Message: "Missing arguments to clamp()!"}
+	}
+	lo, ok := numberToBigInt(args[0])
+	if !ok {
+		return &Error{Message: "First argument to clamp() must be an integer!"}
+	}
+	hi, ok := numberToBigInt(args[1])
+	if !ok {
+		return &Error{Message: "Second argument to clamp() must be an integer!"}
+	}
+	if lo.Cmp(hi) > 0 {
+		return &Error{Message: "Lower bound given to clamp() is greater than the upper bound!"}
+	}
+	switch {
+	case value.Cmp(lo) < 0:
+		return NewIntegerFromBig(lo)
+	case value.Cmp(hi) > 0:
+		return NewIntegerFromBig(hi)
+	default:
+		return NewIntegerFromBig(value)
+	}
+}
+
+func roundInteger(value *big.Int, args []Object) Object {
+	digits, err := digitsArgument("round", args)
+	if err != nil {
+		return err
+	}
+	if digits >= 0 {
+		return NewIntegerFromBig(value)
+	}
+	scale := pow10(-digits)
+	rounded := roundQuotient(value, scale, ROUND_HALF_UP)
+	return NewIntegerFromBig(rounded.Mul(rounded, scale))
+}
+
+func (i *Integer) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	value := big.NewInt(i.Value)
+	switch method {
+	case "abs":
+		return NewIntegerFromBig(value.Abs(value))
+	case "round":
+		return roundInteger(value, args)
+	case "floor", "ceil":
+		return i
+	case "toFixed":
+		digits, err := fixedDigitsArgument(args)
+		if err != nil {
+			return err
+		}
+		return &String{Value: NewDecimalFromInt(value).Rescale(digits, ROUND_HALF_UP).Inspect()}
+	case "clamp":
+		return clampInteger(value, args)
+	case "isNaN":
+		return &Boolean{Value: false}
+	case "toString":
+		base, err := baseArgument(args)
+		if err != nil {
+			return err
+		}
+		return &String{Value: strconv.FormatInt(i.Value, base)}
+	case "sqrt":
+		return &Float{Value: math.Sqrt(float64(i.Value))}
+	default:
+		return nil
+	}
+}
+
+func (bi *BigInteger) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	switch method {
+	case "abs":
+		return NewIntegerFromBig(new(big.Int).Abs(bi.Value))
+	case "round":
+		return roundInteger(bi.Value, args)
+	case "floor", "ceil":
+		return bi
+	case "toFixed":
+		digits, err := fixedDigitsArgument(args)
+		if err != nil {
+			return err
+		}
+		return &String{Value: NewDecimalFromInt(bi.Value).Rescale(digits, ROUND_HALF_UP).Inspect()}
+	case "clamp":
+		return clampInteger(bi.Value, args)
+	case "isNaN":
+		return &Boolean{Value: false}
+	case "toString":
+		base, err := baseArgument(args)
+		if err != nil {
+			return err
+		}
+		return &String{Value: bi.Value.Text(base)}
+	case "sqrt":
+		if bi.Value.Sign() < 0 {
+			return &Float{Value: math.NaN()}
+		}
+		value, _ := new(big.Float).Sqrt(new(big.Float).SetInt(bi.Value)).Float64()
+		return &Float{Value: value}
+	default:
+		return nil
+	}
+}
+
+func (f *Float) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	switch method {
+	case "abs":
+		return &Float{Value: math.Abs(f.Value)}
+	case "round":
+		if len(args) < 1 {
+			return integerFromFloat(roundFloat(f.Value, 0), "round")
+		}
+		digits, err := digitsArgument("round", args)
+		if err != nil {
+			return err
+		}
+		return &Float{Value: roundFloat(f.Value, digits)}
+	case "floor":
+		return integerFromFloat(math.Floor(f.Value), "floor")
+	case "ceil":
+		return integerFromFloat(math.Ceil(f.Value), "ceil")
+	case "toFixed":
+		digits, err := fixedDigitsArgument(args)
+		if err != nil {
+			return err
+		}
+		return &String{Value: formatFixed(f.Value, digits)}
+	case "clamp":
+		if len(args) < 2 {
+			return &Error{Message: "Missing arguments to clamp()!"}
+		}
+		lo, ok := numberToFloat(args[0])
+		if !ok {
+			return &Error{Message: "First argument to clamp() must be a number!"}
+		}
+		hi, ok := numberToFloat(args[1])
+		if !ok {
+			return &Error{Message: "Second argument to clamp() must be a number!"}
+		}
+		if lo > hi {
+			return &Error{Message: "Lower bound given to clamp() is greater than the upper bound!"}
+		}
+		return &Float{Value: math.Min(math.Max(f.Value, lo), hi)}
+	case "isNaN":
+		return &Boolean{Value: math.IsNaN(f.Value)}
+	case "toString":
+		base, err := baseArgument(args)
+		if err != nil {
+			return err
+		}
+		if base == 10 {
+			return &String{Value: f.Inspect()}
+		}
+		if f.Value != math.Trunc(f.Value) || math.IsInf(f.Value, 0) {
+			return &Error{Message: "toString() with a base other than 10 needs a whole number!"}
+		}
+		integer, _ := big.NewFloat(f.Value).Int(nil)
+		return &String{Value: integer.Text(base)}
+	case "sqrt":
+		return &Float{Value: math.Sqrt(f.Value)}
+	default:
+		return nil
+	}
+}
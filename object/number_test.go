@@ -0,0 +1,75 @@
+package object
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNumberMethods(t *testing.T) {
+	huge, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	tests := []struct {
+		receiver Object
+		method   string
+		args     []Object
+		expected string
+	}{
+		{&Integer{Value: -5}, "abs", nil, "5"},
+		{&Integer{Value: 1234}, "round", []Object{&Integer{Value: -2}}, "1200"},
+		{&Integer{Value: 1250}, "round", []Object{&Integer{Value: -2}}, "1300"},
+		{&Integer{Value: 1234}, "toFixed", []Object{&Integer{Value: 2}}, "1234.00"},
+		{&Integer{Value: 9007199254740993}, "toFixed", []Object{&Integer{Value: 1}}, "9007199254740993.0"},
+		{&Integer{Value: math.MaxInt64}, "toFixed", nil, "9223372036854775807"},
+		{&Integer{Value: 7}, "clamp", []Object{&Integer{Value: 1}, &Integer{Value: 5}}, "5"},
+		{&Integer{Value: 255}, "toString", []Object{&Integer{Value: 16}}, "ff"},
+		{&Integer{Value: 16}, "sqrt", nil, "4"},
+		{&Integer{Value: 3}, "isNaN", nil, "false"},
+		{&BigInteger{Value: huge}, "toFixed", []Object{&Integer{Value: 2}}, "123456789012345678901234567890.00"},
+		{&BigInteger{Value: huge}, "round", []Object{&Integer{Value: -29}}, "100000000000000000000000000000"},
+		{&Float{Value: -2.5}, "abs", nil, "2.5"},
+		{&Float{Value: 2.5}, "round", nil, "3"},
+		{&Float{Value: 1.2345}, "round", []Object{&Integer{Value: 2}}, "1.23"},
+		{&Float{Value: 1.005}, "toFixed", []Object{&Integer{Value: 2}}, "1.01"},
+		{&Float{Value: 1.7}, "floor", nil, "1"},
+		{&Float{Value: 1.2}, "ceil", nil, "2"},
+		{&Float{Value: math.NaN()}, "isNaN", nil, "true"},
+		{&Float{Value: 10}, "toString", []Object{&Integer{Value: 2}}, "1010"},
+		{&Boolean{Value: true}, "toString", nil, "true"},
+		{&Boolean{Value: true}, "toInt", nil, "1"},
+	}
+	for _, tt := range tests {
+		result := tt.receiver.InvokeMethod(tt.method, nil, tt.args...)
+		if result == nil {
+			t.Errorf("%s.%s: method not found", tt.receiver.Inspect(), tt.method)
+			continue
+		}
+		if result.Inspect() != tt.expected {
+			t.Errorf("%s.%s(%v): got %s, want %s", tt.receiver.Inspect(), tt.method, tt.args, result.Inspect(), tt.expected)
+		}
+	}
+}
+
+func TestNumberMethodErrors(t *testing.T) {
+	tests := []struct {
+		receiver Object
+		method   string
+		args     []Object
+		expected string
+	}{
+		{&Integer{Value: 1234}, "toFixed", []Object{&Integer{Value: -2}}, "Digits given to toFixed() must not be negative! Digits given -2"},
+		{&Float{Value: 1.5}, "toFixed", []Object{&Integer{Value: -1}}, "Digits given to toFixed() must not be negative! Digits given -1"},
+		{&BigInteger{Value: new(big.Int).Lsh(big.NewInt(1), 70)}, "toFixed", []Object{&Integer{Value: -1}}, "Digits given to toFixed() must not be negative! Digits given -1"},
+		{&Integer{Value: 1}, "toFixed", []Object{&String{Value: "2"}}, "First argument to toFixed() must be an integer!"},
+		{&Integer{Value: 1}, "clamp", []Object{&Integer{Value: 1}}, "Missing arguments to clamp()!"},
+		{&Integer{Value: 1}, "clamp", []Object{&Integer{Value: 5}, &Integer{Value: 1}}, "Lower bound given to clamp() is greater than the upper bound!"},
+		{&Integer{Value: 1}, "toString", []Object{&Integer{Value: 40}}, "Base given to toString() must be between 2 and 36!"},
+		{&Float{Value: 1.5}, "toString", []Object{&Integer{Value: 2}}, "toString() with a base other than 10 needs a whole number!"},
+		{&Float{Value: math.Inf(1)}, "floor", nil, "Cannot convert +Inf to an integer in floor()!"},
+	}
+	for _, tt := range tests {
+		err, ok := tt.receiver.InvokeMethod(tt.method, nil, tt.args...).(*Error)
+		if !ok || err.Message != tt.expected {
+			t.Errorf("%s.%s(%v): got %v, want error %q", tt.receiver.Inspect(), tt.method, tt.args, err, tt.expected)
+		}
+	}
+}
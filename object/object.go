@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"hash/fnv"
 	"io"
+	"math/big"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"unicode/utf8"
@@ -19,16 +21,25 @@ type ObjectType string
 type Object interface {
 	Type() ObjectType
 	Inspect() string
-	InvokeMethod(method string, args ...Object) Object
+	InvokeMethod(method string, apply Applier, args ...Object) Object
 }
 
+type Applier func(fn Object, args ...Object) Object
+
 const (
 	INTEGER_OBJ = "INTEGER"
 	FLOAT_OBJ   = "FLOAT"
+	DECIMAL_OBJ = "DECIMAL"
 	STRING_OBJ  = "STRING"
 	BOOLEAN_OBJ = "BOOLEAN"
 	ARRAY_OBJ   = "ARRAY"
 	HASH_OBJ    = "HASH"
+	RANGE_OBJ   = "RANGE"
+
+	GENERATOR_OBJ = "GENERATOR"
+
+	ENUM_OBJ       = "ENUM"
+	ENUM_VALUE_OBJ = "ENUM_VALUE"
 
 	NULL_OBJ  = "NULL"
 	ERROR_OBJ = "ERROR"
@@ -48,8 +59,18 @@ type Boolean struct {
 
 func (b *Boolean) Type() ObjectType { return BOOLEAN_OBJ }
 func (b *Boolean) Inspect() string  { return fmt.Sprintf("%t", b.Value) }
-func (b *Boolean) InvokeMethod(method string, args ...Object) Object {
-	return nil
+func (b *Boolean) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	switch method {
+	case "toString":
+		return &String{Value: b.Inspect()}
+	case "toInt":
+		if b.Value {
+			return &Integer{Value: 1}
+		}
+		return &Integer{Value: 0}
+	default:
+		return nil
+	}
 }
 
 type Integer struct {
@@ -58,15 +79,19 @@ type Integer struct {
 
 func (i *Integer) Inspect() string  { return fmt.Sprintf("%d", i.Value) }
 func (i *Integer) Type() ObjectType { return INTEGER_OBJ }
-func (i *Integer) InvokeMethod(method string, args ...Object) Object {
-	return nil
+
+type BigInteger struct {
+	Value *big.Int
 }
 
+func (bi *BigInteger) Inspect() string  { return bi.Value.String() }
+func (bi *BigInteger) Type() ObjectType { return INTEGER_OBJ }
+
 type Null struct{}
 
 func (n *Null) Type() ObjectType { return NULL_OBJ }
 func (n *Null) Inspect() string  { return "null" }
-func (n *Null) InvokeMethod(method string, args ...Object) Object {
+func (n *Null) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	return nil
 }
 
@@ -76,14 +101,15 @@ type ReturnValue struct {
 
 func (rv *ReturnValue) Type() ObjectType { return RETURN_VALUE_OBJ }
 func (rv *ReturnValue) Inspect() string  { return rv.Value.Inspect() }
-func (rv *ReturnValue) InvokeMethod(method string, args ...Object) Object {
+func (rv *ReturnValue) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	return nil
 }
 
 type Function struct {
-	Parameters []*ast.Identifier
-	Body       *ast.BlockStatement
-	Env        *Environment
+	Parameters  []*ast.Parameter
+	Body        *ast.BlockStatement
+	Env         *Environment
+	IsGenerator bool
 }
 
 func (f *Function) Type() ObjectType { return FUNCTION_OBJ }
@@ -101,31 +127,43 @@ func (f *Function) Inspect() string {
 	out.WriteString("\n}")
 	return out.String()
 }
-func (f *Function) InvokeMethod(method string, args ...Object) Object {
+func (f *Function) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	return nil
 }
 
 type String struct {
-	Value  string
-	offset int
+	Value string
+	runes []rune
 }
 
-func (s *String) Next() (Object, Object, bool) {
+func (s *String) Runes() []rune {
+	if s.runes == nil {
+		s.runes = []rune(s.Value)
+	}
+	return s.runes
+}
 
-	if s.offset < utf8.RuneCountInString(s.Value) {
-		s.offset++
-		chars := []rune(s.Value)
-		val := &String{Value: string(chars[s.offset-1])}
+type stringIterator struct {
+	runes  []rune
+	offset int
+}
 
-		return val, &Integer{Value: int64(s.offset - 1)}, true
+func (it *stringIterator) Close() {}
+func (it *stringIterator) Next() (Object, Object, bool) {
+	if it.offset < len(it.runes) {
+		it.offset++
+		val := &String{Value: string(it.runes[it.offset-1])}
+
+		return val, &Integer{Value: int64(it.offset - 1)}, true
 	}
 
 	return nil, &Integer{Value: 0}, false
 }
-func (s *String) Reset()           { s.offset = 0 }
+
+func (s *String) Iter() Iterator   { return &stringIterator{runes: s.Runes()} }
 func (s *String) Type() ObjectType { return STRING_OBJ }
 func (s *String) Inspect() string  { return s.Value }
-func (s *String) InvokeMethod(method string, args ...Object) Object {
+func (s *String) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	switch method {
 	case "count":
 		if len(args) < 1 {
@@ -180,18 +218,21 @@ func (s *String) InvokeMethod(method string, args ...Object) Object {
 }
 
 type Builtin struct {
-	Fn func(token token.Token, args ...Object) Object
+	Fn      func(token token.Token, args ...Object) Object
+	NamedFn func(token token.Token, named map[string]Object, args ...Object) Object
+	EnvFn   func(env *Environment, token token.Token, args ...Object) Object
 }
 
 func (b *Builtin) Type() ObjectType { return BUILTIN_OBJ }
 func (b *Builtin) Inspect() string  { return "builtin function" }
-func (b *Builtin) InvokeMethod(method string, args ...Object) Object {
+func (b *Builtin) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	return nil
 }
 
 type Array struct {
 	Elements []Object
-	offset   int
+
+	frozen bool
 }
 
 func (ao *Array) Type() ObjectType { return ARRAY_OBJ }
@@ -206,18 +247,27 @@ func (ao *Array) Inspect() string {
 	out.WriteString("]")
 	return out.String()
 }
-func (ao *Array) Reset() { ao.offset = 0 }
-func (ao *Array) Next() (Object, Object, bool) {
-	if ao.offset < len(ao.Elements) {
-		ao.offset++
 
-		element := ao.Elements[ao.offset-1]
-		return element, &Integer{Value: int64(ao.offset - 1)}, true
+type arrayIterator struct {
+	array  *Array
+	offset int
+	length int
+}
+
+func (it *arrayIterator) Close() {}
+func (it *arrayIterator) Next() (Object, Object, bool) {
+	if it.offset < it.length && it.offset < len(it.array.Elements) {
+		it.offset++
+
+		element := it.array.Elements[it.offset-1]
+		return element, &Integer{Value: int64(it.offset - 1)}, true
 	}
 
 	return nil, &Integer{Value: 0}, false
 }
-func (ao *Array) InvokeMethod(method string, args ...Object) Object {
+
+func (ao *Array) Iter() Iterator { return &arrayIterator{array: ao, length: len(ao.Elements)} }
+func (ao *Array) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	switch method {
 	case "find":
 		if len(args) < 1 {
@@ -234,6 +284,9 @@ func (ao *Array) InvokeMethod(method string, args ...Object) Object {
 		}
 		return &Integer{Value: int64(result)}
 	case "append":
+		if err := ao.checkMutable("append", "to"); err != nil {
+			return err
+		}
 		if len(args) < 1 {
 			return &Error{Message: "Missing argument to append()!"}
 		}
@@ -241,6 +294,9 @@ func (ao *Array) InvokeMethod(method string, args ...Object) Object {
 		ao.Elements = append(ao.Elements, args[0])
 		return &Null{}
 	case "detach":
+		if err := ao.checkMutable("detach", "from"); err != nil {
+			return err
+		}
 		if len(args) < 2 {
 			return &Error{Message: "Missing argument to append()!"}
 		}
@@ -248,18 +304,214 @@ func (ao *Array) InvokeMethod(method string, args ...Object) Object {
 			return &Error{Message: "First argument to detach() must be an integer!"}
 		}
 
-		idx := args[0].(*Integer).Value
-		if idx < 0 || idx >= int64(len(ao.Elements)) {
+		integer, ok := args[0].(*Integer)
+		if !ok || integer.Value < 0 || integer.Value >= int64(len(ao.Elements)) {
 			return &Error{Message: "Index out of range!"}
 		}
+		idx := integer.Value
 
 		ao.Elements = append(ao.Elements[:idx], ao.Elements[idx+1:]...)
 		return &Null{}
+	case "map":
+		if err := checkCallback("map", args); err != nil {
+			return err
+		}
+		result := make([]Object, len(ao.Elements))
+		for i, element := range ao.Elements {
+			mapped := apply(args[0], element)
+			if isError(mapped) {
+				return mapped
+			}
+			result[i] = mapped
+		}
+		return &Array{Elements: result}
+	case "filter":
+		if err := checkCallback("filter", args); err != nil {
+			return err
+		}
+		result := []Object{}
+		for _, element := range ao.Elements {
+			keep := apply(args[0], element)
+			if isError(keep) {
+				return keep
+			}
+			if isTruthy(keep) {
+				result = append(result, element)
+			}
+		}
+		return &Array{Elements: result}
+	case "reduce":
+		if err := checkCallback("reduce", args); err != nil {
+			return err
+		}
+		elements := ao.Elements
+		var accumulator Object
+		if len(args) >= 2 {
+			accumulator = args[1]
+		} else if len(elements) > 0 {
+			accumulator, elements = elements[0], elements[1:]
+		} else {
+			return &Error{Message: "reduce() of an empty array needs an initial value!"}
+		}
+		for _, element := range elements {
+			accumulator = apply(args[0], accumulator, element)
+			if isError(accumulator) {
+				return accumulator
+			}
+		}
+		return accumulator
+	case "forEach":
+		if err := checkCallback("forEach", args); err != nil {
+			return err
+		}
+		for _, element := range ao.Elements {
+			if ret := apply(args[0], element); isError(ret) {
+				return ret
+			}
+		}
+		return &Null{}
+	case "any", "all":
+		if err := checkCallback(method, args); err != nil {
+			return err
+		}
+		want := method == "any"
+		for _, element := range ao.Elements {
+			ret := apply(args[0], element)
+			if isError(ret) {
+				return ret
+			}
+			if isTruthy(ret) == want {
+				return &Boolean{Value: want}
+			}
+		}
+		return &Boolean{Value: !want}
+	case "sortBy":
+		if err := checkCallback("sortBy", args); err != nil {
+			return err
+		}
+		result := make([]Object, len(ao.Elements))
+		copy(result, ao.Elements)
+		var failure Object
+		sort.SliceStable(result, func(i, j int) bool {
+			if failure != nil {
+				return false
+			}
+			ret := apply(args[0], result[i], result[j])
+			switch ret := ret.(type) {
+			case *Integer:
+				return ret.Value < 0
+			case *BigInteger:
+				return ret.Value.Sign() < 0
+			case *Float:
+				return ret.Value < 0
+			case *Boolean:
+				return ret.Value
+			case *Error:
+				failure = ret
+			default:
+				failure = &Error{Message: fmt.Sprintf("Comparator given to sortBy() must return a number or a boolean, got %s!", ret.Type())}
+			}
+			return false
+		})
+		if failure != nil {
+			return failure
+		}
+		return &Array{Elements: result}
+	case "flatMap":
+		if err := checkCallback("flatMap", args); err != nil {
+			return err
+		}
+		result := []Object{}
+		for _, element := range ao.Elements {
+			mapped := apply(args[0], element)
+			if isError(mapped) {
+				return mapped
+			}
+			if array, ok := mapped.(*Array); ok {
+				result = append(result, array.Elements...)
+			} else {
+				result = append(result, mapped)
+			}
+		}
+		return &Array{Elements: result}
+	case "groupBy":
+		if err := checkCallback("groupBy", args); err != nil {
+			return err
+		}
+		groups := NewHash()
+		for _, element := range ao.Elements {
+			key := apply(args[0], element)
+			if isError(key) {
+				return key
+			}
+			hashable, ok := key.(Hashable)
+			if !ok {
+				return &Error{Message: fmt.Sprintf("Key returned to groupBy() is unusable as hash key: %s", key.Type())}
+			}
+			pair, ok := groups.Get(hashable.HashKey())
+			if !ok {
+				pair = HashPair{Key: key, Value: &Array{Elements: []Object{}}}
+				groups.Set(hashable.HashKey(), pair)
+			}
+			group := pair.Value.(*Array)
+			group.Elements = append(group.Elements, element)
+		}
+		return groups
+	case "zip":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to zip()!"}
+		}
+		length := len(ao.Elements)
+		others := make([]*Array, len(args))
+		for i, arg := range args {
+			other, ok := arg.(*Array)
+			if !ok {
+				return &Error{Message: "Arguments to zip() must be arrays!"}
+			}
+			others[i] = other
+			if len(other.Elements) < length {
+				length = len(other.Elements)
+			}
+		}
+		result := make([]Object, length)
+		for i := 0; i < length; i++ {
+			tuple := []Object{ao.Elements[i]}
+			for _, other := range others {
+				tuple = append(tuple, other.Elements[i])
+			}
+			result[i] = &Array{Elements: tuple}
+		}
+		return &Array{Elements: result}
 	default:
 		return nil
 	}
 }
 
+func checkCallback(method string, args []Object) *Error {
+	if len(args) < 1 {
+		return &Error{Message: fmt.Sprintf("Missing argument to %s()!", method)}
+	}
+	if args[0].Type() != FUNCTION_OBJ && args[0].Type() != BUILTIN_OBJ {
+		return &Error{Message: fmt.Sprintf("First argument to %s() must be a function!", method)}
+	}
+	return nil
+}
+
+func isError(obj Object) bool {
+	return obj != nil && obj.Type() == ERROR_OBJ
+}
+
+func isTruthy(obj Object) bool {
+	switch obj := obj.(type) {
+	case *Boolean:
+		return obj.Value
+	case *Null:
+		return false
+	default:
+		return true
+	}
+}
+
 type HashKey struct {
 	Type  ObjectType
 	Value uint64
@@ -277,6 +529,14 @@ func (b *Boolean) HashKey() HashKey {
 func (i *Integer) HashKey() HashKey {
 	return HashKey{Type: i.Type(), Value: uint64(i.Value)}
 }
+func (bi *BigInteger) HashKey() HashKey {
+	h := fnv.New64a()
+	if bi.Value.Sign() < 0 {
+		h.Write([]byte{'-'})
+	}
+	h.Write(bi.Value.Bytes())
+	return HashKey{Type: bi.Type(), Value: h.Sum64()}
+}
 func (s *String) HashKey() HashKey {
 	h := fnv.New64a()
 	h.Write([]byte(s.Value))
@@ -287,55 +547,261 @@ type HashPair struct {
 	Key   Object
 	Value Object
 }
+
+type hashEntry struct {
+	key     HashKey
+	pair    HashPair
+	deleted bool
+}
+
 type Hash struct {
-	Pairs  map[HashKey]HashPair
-	offset int
+	index     map[HashKey]int
+	entries   []hashEntry
+	live      int
+	iterators int
+	frozen    bool
 }
 
-func (h *Hash) Reset() {
-	h.offset = 0
+func NewHash() *Hash {
+	return &Hash{index: make(map[HashKey]int)}
 }
-func (h *Hash) Next() (Object, Object, bool) {
-	if h.offset < len(h.Pairs) {
-		idx := 0
 
-		for _, pair := range h.Pairs {
-			if h.offset == idx {
-				h.offset++
-				return pair.Key, pair.Value, true
-			}
-			idx++
+func (h *Hash) Len() int { return h.live }
+
+func (h *Hash) Get(key HashKey) (HashPair, bool) {
+	i, ok := h.index[key]
+	if !ok {
+		return HashPair{}, false
+	}
+	return h.entries[i].pair, true
+}
+
+func (h *Hash) Set(key HashKey, pair HashPair) {
+	if i, ok := h.index[key]; ok {
+		h.entries[i].pair = pair
+		return
+	}
+	h.index[key] = len(h.entries)
+	h.entries = append(h.entries, hashEntry{key: key, pair: pair})
+	h.live++
+}
+
+func (h *Hash) Delete(key HashKey) bool {
+	i, ok := h.index[key]
+	if !ok {
+		return false
+	}
+	delete(h.index, key)
+	h.entries[i] = hashEntry{deleted: true}
+	h.live--
+	if len(h.entries) > 2*h.live+8 && h.iterators == 0 {
+		h.compact()
+	}
+	return true
+}
+
+func (h *Hash) compact() {
+	entries := make([]hashEntry, 0, h.live)
+	for _, entry := range h.entries {
+		if !entry.deleted {
+			h.index[entry.key] = len(entries)
+			entries = append(entries, entry)
 		}
 	}
+	h.entries = entries
+}
+
+func (h *Hash) Pairs() []HashPair {
+	pairs := make([]HashPair, 0, h.live)
+	for _, entry := range h.entries {
+		if !entry.deleted {
+			pairs = append(pairs, entry.pair)
+		}
+	}
+	return pairs
+}
+
+type hashIterator struct {
+	hash   *Hash
+	offset int
+	length int
+	closed bool
+}
+
+func (it *hashIterator) Close() {
+	if !it.closed {
+		it.closed = true
+		it.hash.iterators--
+	}
+}
+func (it *hashIterator) Next() (Object, Object, bool) {
+	for !it.closed && it.offset < it.length {
+		entry := it.hash.entries[it.offset]
+		it.offset++
+		if !entry.deleted {
+			return entry.pair.Key, entry.pair.Value, true
+		}
+	}
+	it.Close()
 
 	return nil, &Integer{Value: 0}, false
 }
-func (h *Hash) InvokeMethod(method string, args ...Object) Object {
+
+func (h *Hash) Iter() Iterator {
+	h.iterators++
+	return &hashIterator{hash: h, length: len(h.entries)}
+}
+func (h *Hash) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	switch method {
 	case "keys":
-		ents := len(h.Pairs)
-		array := make([]Object, ents)
-
-		i := 0
-		for _, ent := range h.Pairs {
-			array[i] = ent.Key
-			i++
+		array := make([]Object, 0, h.Len())
+		for _, pair := range h.Pairs() {
+			array = append(array, pair.Key)
 		}
 
 		return &Array{Elements: array}
 	case "values":
-		pairs := []Object{}
+		array := make([]Object, 0, h.Len())
+		for _, pair := range h.Pairs() {
+			array = append(array, pair.Value)
+		}
 
-		for _, pair := range h.Pairs {
-			pairs = append(pairs, pair.Value)
+		return &Array{Elements: array}
+	case "entries":
+		array := make([]Object, 0, h.Len())
+		for _, pair := range h.Pairs() {
+			array = append(array, &Array{Elements: []Object{pair.Key, pair.Value}})
 		}
 
-		return &Array{Elements: pairs}
+		return &Array{Elements: array}
+	case "size":
+		return &Integer{Value: int64(h.Len())}
+	case "has":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to has()!"}
+		}
+		key, err := hashKeyOf("has", args[0])
+		if err != nil {
+			return err
+		}
+		_, ok := h.Get(key)
+		return &Boolean{Value: ok}
+	case "get":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to get()!"}
+		}
+		key, err := hashKeyOf("get", args[0])
+		if err != nil {
+			return err
+		}
+		if pair, ok := h.Get(key); ok {
+			return pair.Value
+		}
+		if len(args) >= 2 {
+			return args[1]
+		}
+		return &Null{}
+	case "delete":
+		if err := h.checkMutable("delete", "from"); err != nil {
+			return err
+		}
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to delete()!"}
+		}
+		key, err := hashKeyOf("delete", args[0])
+		if err != nil {
+			return err
+		}
+		return &Boolean{Value: h.Delete(key)}
+	case "merge":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to merge()!"}
+		}
+		other, ok := args[0].(*Hash)
+		if !ok {
+			return &Error{Message: "First argument to merge() must be a hash!"}
+		}
+		deep := false
+		if len(args) >= 2 {
+			flag, ok := args[1].(*Boolean)
+			if !ok {
+				return &Error{Message: "Second argument to merge() must be a boolean!"}
+			}
+			deep = flag.Value
+		}
+		return mergeHashes(h, other, deep)
+	case "filter":
+		if err := checkCallback("filter", args); err != nil {
+			return err
+		}
+		result := NewHash()
+		for _, entry := range h.entries {
+			if entry.deleted {
+				continue
+			}
+			keep := apply(args[0], entry.pair.Key, entry.pair.Value)
+			if isError(keep) {
+				return keep
+			}
+			if isTruthy(keep) {
+				result.Set(entry.key, entry.pair)
+			}
+		}
+		return result
+	case "mapValues":
+		if err := checkCallback("mapValues", args); err != nil {
+			return err
+		}
+		result := NewHash()
+		for _, entry := range h.entries {
+			if entry.deleted {
+				continue
+			}
+			mapped := apply(args[0], entry.pair.Value)
+			if isError(mapped) {
+				return mapped
+			}
+			result.Set(entry.key, HashPair{Key: entry.pair.Key, Value: mapped})
+		}
+		return result
 	default:
 		return nil
 	}
 }
 
+func hashKeyOf(method string, obj Object) (HashKey, *Error) {
+	hashable, ok := obj.(Hashable)
+	if !ok {
+		return HashKey{}, &Error{Message: fmt.Sprintf("Argument to %s() is unusable as hash key: %s", method, obj.Type())}
+	}
+	return hashable.HashKey(), nil
+}
+
+func mergeHashes(base, other *Hash, deep bool) *Hash {
+	result := NewHash()
+	for _, entry := range base.entries {
+		if !entry.deleted {
+			result.Set(entry.key, entry.pair)
+		}
+	}
+	for _, entry := range other.entries {
+		if entry.deleted {
+			continue
+		}
+		if deep {
+			existing, ok := result.Get(entry.key)
+			baseHash, baseOk := existing.Value.(*Hash)
+			otherHash, otherOk := entry.pair.Value.(*Hash)
+			if ok && baseOk && otherOk {
+				result.Set(entry.key, HashPair{Key: existing.Key, Value: mergeHashes(baseHash, otherHash, true)})
+				continue
+			}
+		}
+		result.Set(entry.key, entry.pair)
+	}
+	return result
+}
+
 type Hashable interface {
 	HashKey() HashKey
 }
@@ -344,7 +810,7 @@ func (h *Hash) Type() ObjectType { return HASH_OBJ }
 func (h *Hash) Inspect() string {
 	var out bytes.Buffer
 	pairs := []string{}
-	for _, pair := range h.Pairs {
+	for _, pair := range h.Pairs() {
 		pairs = append(pairs, fmt.Sprintf("%s: %s",
 			pair.Key.Inspect(), pair.Value.Inspect()))
 	}
@@ -354,9 +820,17 @@ func (h *Hash) Inspect() string {
 	return out.String()
 }
 
-type Iterable interface {
-	Reset()
+// Iterator walks a collection independently of any other loop over it.
+// Arrays and hashes fix their length when the iterator is created: elements
+// appended during the loop are not visited, elements removed before they are
+// reached are skipped, and values replaced in place are seen as updated.
+type Iterator interface {
 	Next() (Object, Object, bool)
+	Close()
+}
+
+type Iterable interface {
+	Iter() Iterator
 }
 
 type Quote struct {
@@ -367,7 +841,7 @@ func (q *Quote) Type() ObjectType { return QUOTE_OBJ }
 func (q *Quote) Inspect() string {
 	return "QUOTE(" + q.Node.String() + ")"
 }
-func (q *Quote) InvokeMethod(method string, args ...Object) Object {
+func (q *Quote) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	return nil
 }
 
@@ -392,7 +866,7 @@ func (m *Macro) Inspect() string {
 	out.WriteString("\n}")
 	return out.String()
 }
-func (q *Macro) InvokeMethod(method string, args ...Object) Object {
+func (q *Macro) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	return nil
 }
 
@@ -402,9 +876,6 @@ type Float struct {
 
 func (f *Float) Inspect() string  { return strconv.FormatFloat(f.Value, 'f', -1, 64) }
 func (f *Float) Type() ObjectType { return FLOAT_OBJ }
-func (f *Float) InvokeMethod(method string, args ...Object) Object {
-	return nil
-}
 
 type File struct {
 	File *os.File
@@ -412,7 +883,7 @@ type File struct {
 
 func (f *File) Inspect() string  { return f.File.Name() }
 func (f *File) Type() ObjectType { return FILE_OBJ }
-func (f *File) InvokeMethod(method string, args ...Object) Object {
+func (f *File) InvokeMethod(method string, apply Applier, args ...Object) Object {
 	switch method {
 	case "open":
 		if len(args) < 2 {
@@ -0,0 +1,261 @@
+package object
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+type Range struct {
+	Start     int64
+	Stop      int64
+	Step      int64
+	Inclusive bool
+}
+
+func (r *Range) Len() int64 {
+	var span uint64
+	switch {
+	case r.Step > 0 && r.Inclusive && r.Stop >= r.Start:
+		span = uint64(r.Stop) - uint64(r.Start)
+	case r.Step > 0 && !r.Inclusive && r.Stop > r.Start:
+		span = uint64(r.Stop) - uint64(r.Start) - 1
+	case r.Step < 0 && r.Inclusive && r.Start >= r.Stop:
+		span = uint64(r.Start) - uint64(r.Stop)
+	case r.Step < 0 && !r.Inclusive && r.Start > r.Stop:
+		span = uint64(r.Start) - uint64(r.Stop) - 1
+	default:
+		return 0
+	}
+	step := uint64(r.Step)
+	if r.Step < 0 {
+		step = uint64(-r.Step)
+	}
+	if span/step >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(span/step + 1)
+}
+
+func (r *Range) At(i int64) int64 {
+	return r.Start + i*r.Step
+}
+
+func (r *Range) Contains(value int64) bool {
+	length := r.Len()
+	if length == 0 {
+		return false
+	}
+	first, last := r.Start, r.At(length-1)
+	if r.Step < 0 {
+		first, last = last, first
+	}
+	if value < first || value > last {
+		return false
+	}
+	diff := new(big.Int).Sub(big.NewInt(value), big.NewInt(r.Start))
+	return diff.Rem(diff, big.NewInt(r.Step)).Sign() == 0
+}
+
+func (r *Range) Reverse() *Range {
+	length := r.Len()
+	if length == 0 {
+		return &Range{Start: r.Start, Stop: r.Start, Step: -r.Step}
+	}
+	return &Range{Start: r.At(length - 1), Stop: r.Start, Step: -r.Step, Inclusive: true}
+}
+
+// MaxArrayLength caps how many elements a range may be expanded into.
+const MaxArrayLength = 1 << 24
+
+func (r *Range) ToArray() Object {
+	length := r.Len()
+	if length > MaxArrayLength {
+		return &Error{Message: fmt.Sprintf("Range %s has %d elements, too many to convert to an array!", r.Inspect(), length)}
+	}
+	elements := make([]Object, length)
+	for i := int64(0); i < length; i++ {
+		elements[i] = &Integer{Value: r.At(i)}
+	}
+	return &Array{Elements: elements}
+}
+
+type rangeIterator struct {
+	rng    Range
+	length int64
+	offset int64
+}
+
+func (it *rangeIterator) Close() {}
+func (it *rangeIterator) Next() (Object, Object, bool) {
+	if it.offset < it.length {
+		it.offset++
+		return &Integer{Value: it.rng.At(it.offset - 1)}, &Integer{Value: it.offset - 1}, true
+	}
+
+	return nil, &Integer{Value: 0}, false
+}
+
+func (r *Range) Iter() Iterator { return &rangeIterator{rng: *r, length: r.Len()} }
+
+func (r *Range) Type() ObjectType { return RANGE_OBJ }
+func (r *Range) Inspect() string {
+	operator := ".."
+	if r.Inclusive {
+		operator = "..="
+	}
+	out := fmt.Sprintf("%d%s%d", r.Start, operator, r.Stop)
+	if r.Step != 1 {
+		out = fmt.Sprintf("(%s).step(%d)", out, r.Step)
+	}
+	return out
+}
+func (r *Range) InvokeMethod(method string, apply Applier, args ...Object) Object {
+	switch method {
+	case "step":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to step()!"}
+		}
+		step, ok := args[0].(*Integer)
+		if !ok || step.Value <= 0 {
+			return &Error{Message: "First argument to step() must be a positive integer!"}
+		}
+		direction := int64(1)
+		if r.Step < 0 {
+			direction = -1
+		}
+		return &Range{Start: r.Start, Stop: r.Stop, Step: direction * step.Value, Inclusive: r.Inclusive}
+	case "len":
+		return &Integer{Value: r.Len()}
+	case "contains":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to contains()!"}
+		}
+		value, ok := args[0].(*Integer)
+		return &Boolean{Value: ok && r.Contains(value.Value)}
+	case "reverse":
+		return r.Reverse()
+	case "toArray":
+		return r.ToArray()
+	case "find":
+		if len(args) < 1 {
+			return &Error{Message: "Missing argument to find()!"}
+		}
+		value, ok := args[0].(*Integer)
+		if !ok || !r.Contains(value.Value) {
+			return &Integer{Value: -1}
+		}
+		return &Integer{Value: (value.Value - r.Start) / r.Step}
+	case "map":
+		if err := checkCallback("map", args); err != nil {
+			return err
+		}
+		result := []Object{}
+		if err := r.each(func(element Object) Object {
+			mapped := apply(args[0], element)
+			if isError(mapped) {
+				return mapped
+			}
+			result = append(result, mapped)
+			return nil
+		}); err != nil {
+			return err
+		}
+		return &Array{Elements: result}
+	case "filter":
+		if err := checkCallback("filter", args); err != nil {
+			return err
+		}
+		result := []Object{}
+		if err := r.each(func(element Object) Object {
+			keep := apply(args[0], element)
+			if isError(keep) {
+				return keep
+			}
+			if isTruthy(keep) {
+				result = append(result, element)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return &Array{Elements: result}
+	case "reduce":
+		if err := checkCallback("reduce", args); err != nil {
+			return err
+		}
+		var accumulator Object
+		if len(args) >= 2 {
+			accumulator = args[1]
+		} else if r.Len() == 0 {
+			return &Error{Message: "reduce() of an empty range needs an initial value!"}
+		}
+		if err := r.each(func(element Object) Object {
+			if accumulator == nil {
+				accumulator = element
+				return nil
+			}
+			accumulator = apply(args[0], accumulator, element)
+			if isError(accumulator) {
+				return accumulator
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return accumulator
+	case "forEach":
+		if err := checkCallback("forEach", args); err != nil {
+			return err
+		}
+		if err := r.each(func(element Object) Object {
+			if ret := apply(args[0], element); isError(ret) {
+				return ret
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		return &Null{}
+	case "any", "all":
+		if err := checkCallback(method, args); err != nil {
+			return err
+		}
+		want := method == "any"
+		if ret := r.each(func(element Object) Object {
+			ret := apply(args[0], element)
+			if isError(ret) {
+				return ret
+			}
+			if isTruthy(ret) == want {
+				return &Boolean{Value: want}
+			}
+			return nil
+		}); ret != nil {
+			return ret
+		}
+		return &Boolean{Value: !want}
+	case "sortBy", "flatMap", "groupBy", "zip":
+		array := r.ToArray()
+		if isError(array) {
+			return array
+		}
+		return array.InvokeMethod(method, apply, args...)
+	case "append", "detach":
+		return &Error{Message: fmt.Sprintf("Cannot %s() on a range, convert it with toArray() first!", method)}
+	default:
+		return nil
+	}
+}
+
+// each calls fn with the values of the range in order without building an
+// array, stopping at the first non-nil result and returning it.
+func (r *Range) each(fn func(element Object) Object) Object {
+	length := r.Len()
+	for i := int64(0); i < length; i++ {
+		if ret := fn(&Integer{Value: r.At(i)}); ret != nil {
+			return ret
+		}
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package object
+
+import "testing"
+
+func TestStringRunesAreCached(t *testing.T) {
+	s := &String{Value: "héllo"}
+	first := s.Runes()
+	if len(first) != 5 || first[1] != 'é' {
+		t.Fatalf("got runes %q, want %q", string(first), "héllo")
+	}
+	if second := s.Runes(); &second[0] != &first[0] {
+		t.Errorf("Runes() decoded the string again instead of reusing the cache")
+	}
+}
@@ -1,8 +1,11 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"strconv"
+	"strings"
 
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/file"
@@ -13,10 +16,19 @@ import (
 const (
 	_ int = iota
 	LOWEST
+	LAMBDA
+	TERNARY
+	PIPE
+	NULLISH
 	EQUALS
 	LOGICAL
 	LESSGREATER_EQ
 	LESSGREATER
+	RANGE
+	BIT_OR
+	BIT_XOR
+	BIT_AND
+	SHIFT
 	SUM
 	PRODUCT
 	MODULO
@@ -27,23 +39,35 @@ const (
 )
 
 var precedences = map[token.TokenType]int{
-	token.EQ:       EQUALS,
-	token.NOT_EQ:   EQUALS,
-	token.LT:       LESSGREATER,
-	token.GT:       LESSGREATER,
-	token.LT_EQ:    LESSGREATER_EQ,
-	token.GT_EQ:    LESSGREATER_EQ,
-	token.PLUS:     SUM,
-	token.MINUS:    SUM,
-	token.SLASH:    PRODUCT,
-	token.ASTERISK: PRODUCT,
-	token.AND:      LOGICAL,
-	token.OR:       LOGICAL,
-	token.MODULO:   MODULO,
-	token.CARET:    CARET,
-	token.LPAREN:   CALL,
-	token.DOT:      CALL,
-	token.LBRACKET: INDEX,
+	token.ARROW:        LAMBDA,
+	token.QUESTION:     TERNARY,
+	token.PIPE:         PIPE,
+	token.NULLISH:      NULLISH,
+	token.OPTIONAL_DOT: CALL,
+	token.EQ:           EQUALS,
+	token.NOT_EQ:       EQUALS,
+	token.LT:           LESSGREATER,
+	token.GT:           LESSGREATER,
+	token.LT_EQ:        LESSGREATER_EQ,
+	token.GT_EQ:        LESSGREATER_EQ,
+	token.DOT_DOT:      RANGE,
+	token.DOT_DOT_EQ:   RANGE,
+	token.BIT_OR:       BIT_OR,
+	token.TILDE:        BIT_XOR,
+	token.BIT_AND:      BIT_AND,
+	token.SHIFT_LEFT:   SHIFT,
+	token.SHIFT_RIGHT:  SHIFT,
+	token.PLUS:         SUM,
+	token.MINUS:        SUM,
+	token.SLASH:        PRODUCT,
+	token.ASTERISK:     PRODUCT,
+	token.AND:          LOGICAL,
+	token.OR:           LOGICAL,
+	token.MODULO:       MODULO,
+	token.CARET:        CARET,
+	token.LPAREN:       CALL,
+	token.DOT:          CALL,
+	token.LBRACKET:     INDEX,
 }
 
 type (
@@ -63,6 +87,8 @@ type Parser struct {
 	prefixParseFns  map[token.TokenType]prefixParseFn
 	infixParseFns   map[token.TokenType]infixParseFn
 	postfixParseFns map[token.TokenType]postfixParseFn
+
+	functions []*ast.FunctionLiteral
 }
 
 func New(l *lexer.Lexer) *Parser {
@@ -73,11 +99,16 @@ func New(l *lexer.Lexer) *Parser {
 
 	p.prefixParseFns = make(map[token.TokenType]prefixParseFn)
 	p.registerPrefix(token.IDENTIFIER, p.parseIdentifier)
+	p.registerPrefix(token.YIELD, p.parseYieldExpression)
+	p.registerPrefix(token.MATCH, p.parseMatchExpression)
+	p.registerPrefix(token.ELLIPSIS, p.parseSpreadExpression)
 	p.registerPrefix(token.INT, p.parseIntegerLiteral)
+	p.registerPrefix(token.ILLEGAL, p.parseIllegal)
 	p.registerPrefix(token.STRING, p.parseStringLiteral)
 
 	p.registerPrefix(token.BANG, p.parsePrefixExpression)
 	p.registerPrefix(token.MINUS, p.parsePrefixExpression)
+	p.registerPrefix(token.TILDE, p.parsePrefixExpression)
 	p.registerPrefix(token.TRUE, p.parseBoolean)
 	p.registerPrefix(token.FALSE, p.parseBoolean)
 	p.registerPrefix(token.NULL, p.parseNull)
@@ -94,6 +125,7 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.SLASH, p.parseInfixExpression)
 	p.registerInfix(token.ASTERISK, p.parseInfixExpression)
 	p.registerPrefix(token.FLOAT, p.parseFloatLiteral)
+	p.registerPrefix(token.DECIMAL, p.parseDecimalLiteral)
 	p.registerInfix(token.MODULO, p.parseInfixExpression)
 	p.registerInfix(token.AND, p.parseInfixExpression)
 	p.registerInfix(token.OR, p.parseInfixExpression)
@@ -104,6 +136,18 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerInfix(token.LT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.GT_EQ, p.parseInfixExpression)
 	p.registerInfix(token.CARET, p.parseInfixExpression)
+	p.registerInfix(token.PIPE, p.parsePipelineExpression)
+	p.registerInfix(token.QUESTION, p.parseConditionalExpression)
+	p.registerInfix(token.NULLISH, p.parseInfixExpression)
+	p.registerInfix(token.OPTIONAL_DOT, p.parseOptionalChain)
+	p.registerInfix(token.DOT_DOT, p.parseInfixExpression)
+	p.registerInfix(token.DOT_DOT_EQ, p.parseInfixExpression)
+	p.registerInfix(token.BIT_AND, p.parseInfixExpression)
+	p.registerInfix(token.BIT_OR, p.parseInfixExpression)
+	p.registerInfix(token.TILDE, p.parseInfixExpression)
+	p.registerInfix(token.SHIFT_LEFT, p.parseInfixExpression)
+	p.registerInfix(token.SHIFT_RIGHT, p.parseInfixExpression)
+	p.registerInfix(token.ARROW, p.parseArrowFunction)
 
 	p.registerPrefix(token.LPAREN, p.parseGroupedExpression)
 	p.registerInfix(token.LPAREN, p.parseCallExpression)
@@ -148,10 +192,47 @@ func (p *Parser) parseStringLiteral() ast.Expression {
 
 func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
 	exp := &ast.CallExpression{Token: p.curToken, Function: function}
-	exp.Arguments = p.parseExpressionList(token.RPAREN)
+	exp.Arguments = p.parseCallArguments()
 	return exp
 }
 
+func (p *Parser) parseCallArguments() []ast.Expression {
+	args := []ast.Expression{}
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return args
+	}
+
+	named := false
+	for {
+		p.nextToken()
+		if p.curTokenIs(token.IDENTIFIER) && p.peekTokenIs(token.COLON) {
+			arg := &ast.NamedArgument{Token: p.curToken, Name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+			p.nextToken()
+			p.nextToken()
+			arg.Value = p.parseExpression(LOWEST)
+			args = append(args, arg)
+			named = true
+		} else {
+			if named {
+				msg := fmt.Sprintf("File: %s: Line %d: positional argument follows named argument",
+					file.GetFileName(), p.curToken.Line+1)
+				p.errors = append(p.errors, msg)
+				return nil
+			}
+			args = append(args, p.parseExpression(LOWEST))
+		}
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		p.nextToken()
+	}
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	return args
+}
+
 func (p *Parser) parseBoolean() ast.Expression {
 	return &ast.Boolean{Token: p.curToken, Value: p.curTokenIs(token.TRUE)}
 }
@@ -210,14 +291,29 @@ func (p *Parser) parseFunctionLiteral() ast.Expression {
 	if !p.expectPeek(token.LPAREN) {
 		return nil
 	}
-	lit.Parameters = p.parseFunctionParameters()
+	lit.Parameters = p.parseParameters()
 	if !p.expectPeek(token.LBRACE) {
 		return nil
 	}
+	p.functions = append(p.functions, lit)
 	lit.Body = p.parseBlockStatement()
+	p.functions = p.functions[:len(p.functions)-1]
 	return lit
 }
 
+func (p *Parser) parseYieldExpression() ast.Expression {
+	expression := &ast.YieldExpression{Token: p.curToken}
+	if len(p.functions) == 0 {
+		msg := fmt.Sprintf("File: %s: Line %d: yield outside of a function", file.GetFileName(), p.curToken.Line+1)
+		p.errors = append(p.errors, msg)
+	} else {
+		p.functions[len(p.functions)-1].IsGenerator = true
+	}
+	p.nextToken()
+	expression.Value = p.parseExpression(LOWEST)
+	return expression
+}
+
 func (p *Parser) parseFunctionParameters() []*ast.Identifier {
 	identifiers := []*ast.Identifier{}
 	if p.peekTokenIs(token.RPAREN) {
@@ -239,6 +335,156 @@ func (p *Parser) parseFunctionParameters() []*ast.Identifier {
 	return identifiers
 }
 
+func (p *Parser) parseParameters() []*ast.Parameter {
+	parameters := []*ast.Parameter{}
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return parameters
+	}
+	p.nextToken()
+	for {
+		parameter := p.parseParameter()
+		if parameter == nil {
+			return nil
+		}
+		parameters = append(parameters, parameter)
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		if parameter.Rest {
+			msg := fmt.Sprintf("File: %s: Line %d: rest parameter %s must be the last parameter",
+				file.GetFileName(), p.curToken.Line+1, parameter.String())
+			p.errors = append(p.errors, msg)
+			return nil
+		}
+		p.nextToken()
+		p.nextToken()
+	}
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	return parameters
+}
+
+func (p *Parser) parseParameter() *ast.Parameter {
+	if p.curTokenIs(token.ELLIPSIS) {
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		return &ast.Parameter{Target: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}, Rest: true}
+	}
+
+	parameter := &ast.Parameter{Target: p.parseBindingTarget()}
+	if parameter.Target == nil {
+		return nil
+	}
+	if p.peekTokenIs(token.ASSIGN) {
+		p.nextToken()
+		p.nextToken()
+		parameter.Default = p.parseExpression(LOWEST)
+	}
+	return parameter
+}
+
+func (p *Parser) parseSpreadExpression() ast.Expression {
+	expression := &ast.SpreadExpression{Token: p.curToken}
+	p.nextToken()
+	expression.Value = p.parseExpression(LOWEST)
+	return expression
+}
+
+func (p *Parser) parseBindingTarget() ast.Expression {
+	switch p.curToken.Type {
+	case token.IDENTIFIER:
+		return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	case token.LBRACKET:
+		return p.parseArrayPattern()
+	case token.LBRACE:
+		return p.parseHashPattern()
+	default:
+		msg := fmt.Sprintf("File: %s: Line %d: expected identifier or destructuring pattern, got %s",
+			file.GetFileName(), p.curToken.Line+1, p.curToken.Type)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+}
+
+func (p *Parser) parsePatternRest() *ast.Identifier {
+	if p.peekTokenIs(token.RBRACKET) || p.peekTokenIs(token.RBRACE) {
+		return &ast.Identifier{Token: p.curToken, Value: "_"}
+	}
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+}
+
+func (p *Parser) parseArrayPattern() ast.Expression {
+	pattern := &ast.ArrayPattern{Token: p.curToken}
+	for !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		if p.curTokenIs(token.ELLIPSIS) {
+			if pattern.Rest = p.parsePatternRest(); pattern.Rest == nil {
+				return nil
+			}
+			break
+		}
+		element := p.parseBindingTarget()
+		if element == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, element)
+		if !p.peekTokenIs(token.RBRACKET) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+	return pattern
+}
+
+func (p *Parser) parseHashPattern() ast.Expression {
+	pattern := &ast.HashPattern{Token: p.curToken}
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		if p.curTokenIs(token.ELLIPSIS) {
+			if pattern.Rest = p.parsePatternRest(); pattern.Rest == nil {
+				return nil
+			}
+			break
+		}
+		if !p.curTokenIs(token.IDENTIFIER) && !p.curTokenIs(token.STRING) {
+			msg := fmt.Sprintf("File: %s: Line %d: expected key in hash pattern, got %s",
+				file.GetFileName(), p.curToken.Line+1, p.curToken.Type)
+			p.errors = append(p.errors, msg)
+			return nil
+		}
+		pair := &ast.HashPatternPair{Key: p.curToken.Literal}
+		if p.peekTokenIs(token.COLON) {
+			p.nextToken()
+			p.nextToken()
+			pair.Target = p.parseBindingTarget()
+			if pair.Target == nil {
+				return nil
+			}
+		} else if p.curTokenIs(token.IDENTIFIER) {
+			pair.Target = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		} else {
+			p.peekError(token.COLON)
+			return nil
+		}
+		pattern.Pairs = append(pattern.Pairs, pair)
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+	return pattern
+}
+
 func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	block := &ast.BlockStatement{Token: p.curToken}
 	block.Statements = []ast.Statement{}
@@ -251,15 +497,236 @@ func (p *Parser) parseBlockStatement() *ast.BlockStatement {
 	return block
 }
 
+// parseGroupedExpression parses a parenthesised expression. The group may
+// also be a parameter list such as (a, b = 2, ...rest) or ({x}), which is
+// only valid when => follows and is then turned into an arrow function
+// without parsing the group a second time.
 func (p *Parser) parseGroupedExpression() ast.Expression {
-	p.nextToken()
-	exp := p.parseExpression(LOWEST)
+	group := []*ast.Parameter{}
+	if p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		return p.parseParameterGroup(group)
+	}
 
+	for {
+		p.nextToken()
+		item := p.parseGroupItem()
+		if item == nil {
+			return nil
+		}
+		group = append(group, item)
+		if !p.peekTokenIs(token.COMMA) {
+			break
+		}
+		if item.Rest {
+			msg := fmt.Sprintf("File: %s: Line %d: rest parameter %s must be the last parameter",
+				file.GetFileName(), p.curToken.Line+1, item.String())
+			p.errors = append(p.errors, msg)
+			return nil
+		}
+		p.nextToken()
+	}
 	if !p.expectPeek(token.RPAREN) {
 		return nil
 	}
 
-	return exp
+	if item := group[0]; len(group) == 1 && !item.Rest && item.Default == nil && !isPattern(item.Target) {
+		return item.Target
+	}
+	return p.parseParameterGroup(group)
+}
+
+func (p *Parser) parseGroupItem() *ast.Parameter {
+	if p.curTokenIs(token.ELLIPSIS) {
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		return &ast.Parameter{Target: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}, Rest: true}
+	}
+
+	item := &ast.Parameter{Target: p.parseLiteralOrPattern()}
+	if item.Target == nil {
+		return nil
+	}
+	if p.peekTokenIs(token.ASSIGN) {
+		p.nextToken()
+		p.nextToken()
+		item.Default = p.parseExpression(LOWEST)
+	}
+	return item
+}
+
+func (p *Parser) parseParameterGroup(group []*ast.Parameter) ast.Expression {
+	if !p.expectPeek(token.ARROW) {
+		return nil
+	}
+	lit := &ast.FunctionLiteral{Token: p.curToken, IsArrow: true, Parameters: group}
+	for _, parameter := range lit.Parameters {
+		if parameter.Target = p.bindingTargetOf(parameter.Target); parameter.Target == nil {
+			return nil
+		}
+	}
+	return p.parseArrowBody(lit)
+}
+
+// parseLiteralOrPattern parses an expression in which array and hash literals
+// may also use destructuring shorthand like {name, ...rest}. Such a literal
+// comes back as a pattern, which only arrow function parameters accept.
+func (p *Parser) parseLiteralOrPattern() ast.Expression {
+	var left ast.Expression
+	switch p.curToken.Type {
+	case token.LBRACKET:
+		left = p.parseArrayLiteralOrPattern()
+	case token.LBRACE:
+		left = p.parseHashLiteralOrPattern()
+	default:
+		return p.parseExpression(LOWEST)
+	}
+	if left == nil || isPattern(left) {
+		return left
+	}
+	return p.parseInfixExpressions(left, LOWEST)
+}
+
+func (p *Parser) parseArrayLiteralOrPattern() ast.Expression {
+	array := &ast.ArrayLiteral{Token: p.curToken, Elements: []ast.Expression{}}
+	pattern := false
+	for !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		element := p.parseLiteralOrPattern()
+		if element == nil {
+			return nil
+		}
+		pattern = pattern || isPattern(element)
+		array.Elements = append(array.Elements, element)
+		if !p.peekTokenIs(token.RBRACKET) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+	if pattern {
+		return p.bindingTargetOf(array)
+	}
+	return array
+}
+
+func (p *Parser) parseHashLiteralOrPattern() ast.Expression {
+	hash := &ast.HashLiteral{Token: p.curToken, Pairs: []*ast.HashLiteralPair{}}
+	var rest *ast.Identifier
+	pattern := false
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		if p.curTokenIs(token.ELLIPSIS) {
+			if rest = p.parsePatternRest(); rest == nil {
+				return nil
+			}
+			pattern = true
+			break
+		}
+		if p.curTokenIs(token.IDENTIFIER) && (p.peekTokenIs(token.COMMA) || p.peekTokenIs(token.RBRACE)) {
+			ident := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+			hash.Pairs = append(hash.Pairs, &ast.HashLiteralPair{Key: ident, Value: ident})
+			pattern = true
+		} else {
+			key := p.parseExpression(LOWEST)
+			if !p.expectPeek(token.COLON) {
+				return nil
+			}
+			p.nextToken()
+			value := p.parseLiteralOrPattern()
+			if value == nil {
+				return nil
+			}
+			pattern = pattern || isPattern(value)
+			hash.Pairs = append(hash.Pairs, &ast.HashLiteralPair{Key: key, Value: value})
+		}
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+	if !pattern {
+		return hash
+	}
+	target := p.bindingTargetOf(hash)
+	if target, ok := target.(*ast.HashPattern); ok {
+		target.Rest = rest
+	}
+	return target
+}
+
+// bindingTargetOf reinterprets an expression parsed inside a group as the
+// binding target of an arrow function parameter.
+func (p *Parser) bindingTargetOf(exp ast.Expression) ast.Expression {
+	switch exp := exp.(type) {
+	case nil:
+		return nil
+	case *ast.Identifier, *ast.ArrayPattern, *ast.HashPattern:
+		return exp
+	case *ast.ArrayLiteral:
+		pattern := &ast.ArrayPattern{Token: exp.Token}
+		for i, element := range exp.Elements {
+			if spread, ok := element.(*ast.SpreadExpression); ok && i == len(exp.Elements)-1 {
+				if pattern.Rest, ok = spread.Value.(*ast.Identifier); ok {
+					break
+				}
+			}
+			target := p.bindingTargetOf(element)
+			if target == nil {
+				return nil
+			}
+			pattern.Elements = append(pattern.Elements, target)
+		}
+		return pattern
+	case *ast.HashLiteral:
+		pattern := &ast.HashPattern{Token: exp.Token}
+		for _, pair := range exp.Pairs {
+			key, ok := hashPatternKey(pair.Key)
+			if !ok {
+				p.invalidParameterError(pair.Key)
+				return nil
+			}
+			target := p.bindingTargetOf(pair.Value)
+			if target == nil {
+				return nil
+			}
+			pattern.Pairs = append(pattern.Pairs, &ast.HashPatternPair{Key: key, Target: target})
+		}
+		return pattern
+	default:
+		p.invalidParameterError(exp)
+		return nil
+	}
+}
+
+func hashPatternKey(key ast.Expression) (string, bool) {
+	switch key := key.(type) {
+	case *ast.Identifier:
+		return key.Value, true
+	case *ast.StringLiteral:
+		return key.Value, true
+	default:
+		return "", false
+	}
+}
+
+func isPattern(exp ast.Expression) bool {
+	switch exp.(type) {
+	case *ast.ArrayPattern, *ast.HashPattern:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *Parser) invalidParameterError(exp ast.Expression) {
+	msg := fmt.Sprintf("File: %s: Line %d: invalid arrow function parameter %s",
+		file.GetFileName(), p.curToken.Line+1, exp.String())
+	p.errors = append(p.errors, msg)
 }
 
 func (p *Parser) parsePrefixExpression() ast.Expression {
@@ -289,12 +756,88 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	return expression
 }
 
+func (p *Parser) parseConditionalExpression(condition ast.Expression) ast.Expression {
+	expression := &ast.ConditionalExpression{Token: p.curToken, Condition: condition}
+	p.nextToken()
+	expression.Consequence = p.parseExpression(LOWEST)
+	if !p.expectPeek(token.COLON) {
+		return nil
+	}
+	p.nextToken()
+	expression.Alternative = p.parseExpression(LOWEST)
+	return expression
+}
+
+func (p *Parser) parseOptionalChain(left ast.Expression) ast.Expression {
+	tok := p.curToken
+	switch {
+	case p.peekTokenIs(token.LBRACKET):
+		p.nextToken()
+		switch exp := p.parseIndexExpression(left).(type) {
+		case *ast.IndexExpression:
+			exp.Optional = true
+			return exp
+		case *ast.SliceExpression:
+			exp.Optional = true
+			return exp
+		default:
+			return nil
+		}
+	case isMemberName(p.peekToken):
+		p.nextToken()
+		name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		if !p.peekTokenIs(token.LPAREN) {
+			return &ast.IndexExpression{Token: p.curToken, Left: left, Index: &ast.StringLiteral{Token: p.curToken, Value: name.Value}, Optional: true, Member: true}
+		}
+		p.nextToken()
+		return &ast.ObjectCallExpression{Token: tok, Object: left, Call: p.parseCallExpression(name), Optional: true}
+	default:
+		msg := fmt.Sprintf("File: %s: Line %d: expected name or [ after ?., got %s",
+			file.GetFileName(), p.curToken.Line+1, p.peekToken.Type)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+}
+
+func (p *Parser) parsePipelineExpression(left ast.Expression) ast.Expression {
+	expression := &ast.PipelineExpression{Token: p.curToken, Left: left}
+	precedence := p.curPrecedence()
+	p.nextToken()
+	expression.Right = p.parseExpression(precedence)
+	return expression
+}
+
 func (p *Parser) parseIdentifier() ast.Expression {
 	return &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 }
 
+func (p *Parser) parseArrowFunction(left ast.Expression) ast.Expression {
+	target := p.bindingTargetOf(left)
+	if target == nil {
+		return nil
+	}
+	lit := &ast.FunctionLiteral{Token: p.curToken, IsArrow: true, Parameters: []*ast.Parameter{{Target: target}}}
+	return p.parseArrowBody(lit)
+}
+
+func (p *Parser) parseArrowBody(lit *ast.FunctionLiteral) ast.Expression {
+	p.functions = append(p.functions, lit)
+	defer func() { p.functions = p.functions[:len(p.functions)-1] }()
+	if p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+		lit.Body = p.parseBlockStatement()
+		return lit
+	}
+
+	p.nextToken()
+	ret := token.Token{Type: token.RETURN, Literal: "return", Line: p.curToken.Line}
+	body := &ast.ReturnStatement{Token: ret, ReturnValue: p.parseExpression(LOWEST)}
+	lit.Body = &ast.BlockStatement{Token: ret, Statements: []ast.Statement{body}}
+	return lit
+}
+
 func (p *Parser) noPrefixParseFnError(t token.TokenType) {
-	msg := fmt.Sprintf("File: %s: Line %d: no prefix parse function for %s found", file.GetFileName(), p.curToken.Line, t)
+	msg := fmt.Sprintf("File: %s: Line %d: no prefix parse function for %s found", file.GetFileName(), p.curToken.Line+1, t)
 	p.errors = append(p.errors, msg)
 }
 
@@ -312,9 +855,11 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 		return nil
 	}
 
-	leftExp := prefix()
+	return p.parseInfixExpressions(prefix(), precedence)
+}
 
-	for !p.peekTokenIs(token.SEMICOLON) && precedence < p.peekPrecedence() {
+func (p *Parser) parseInfixExpressions(leftExp ast.Expression, precedence int) ast.Expression {
+	for leftExp != nil && !p.peekTokenIs(token.SEMICOLON) && precedence < p.peekPrecedence() {
 		infix := p.infixParseFns[p.peekToken.Type]
 		if infix == nil {
 			return leftExp
@@ -329,6 +874,7 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 func (p *Parser) parsePostfixExpression() ast.Expression {
 	expression := &ast.PostfixExpression{
 		Token:    p.prevToken,
+		Name:     &ast.Identifier{Token: p.prevToken, Value: p.prevToken.Literal},
 		Operator: &ast.StringLiteral{Value: p.curToken.Literal},
 	}
 	return expression
@@ -359,10 +905,14 @@ func (p *Parser) parseStatement() ast.Statement {
 		return p.parseAssignStatement()
 	case token.MUTATE:
 		return p.parseAssignStatement()
+	case token.CONST:
+		return p.parseAssignStatement()
 	case token.RETURN:
 		return p.parseReturnStatement()
 	case token.IMPORT:
 		return p.parseImportStatement()
+	case token.ENUM:
+		return p.parseEnumStatement()
 	default:
 		return p.parseExpressionStatement()
 	}
@@ -387,12 +937,12 @@ func (p *Parser) expectPeek(t token.TokenType) bool {
 }
 
 func (p *Parser) Errors() []string {
-	return p.errors
+	return append(p.l.Errors(), p.errors...)
 }
 
 func (p *Parser) peekError(t token.TokenType) {
 	msg := fmt.Sprintf("File: %s: Line: %d: expected next token to be %s, got %s instead",
-		file.GetFileName(), p.curToken.Line, t, p.peekToken.Type)
+		file.GetFileName(), p.curToken.Line+1, t, p.peekToken.Type)
 	p.errors = append(p.errors, msg)
 }
 
@@ -406,11 +956,19 @@ func (p *Parser) registerInfix(tokenType token.TokenType, fn infixParseFn) {
 
 func (p *Parser) parseAssignStatement() *ast.AssignStatement {
 	stmt := &ast.AssignStatement{Token: p.curToken}
-	if !p.expectPeek(token.IDENTIFIER) {
-		return nil
+	if p.peekTokenIs(token.LBRACKET) || p.peekTokenIs(token.LBRACE) {
+		p.nextToken()
+		stmt.Pattern = p.parseBindingTarget()
+		if stmt.Pattern == nil {
+			return nil
+		}
+	} else {
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 	}
 
-	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
 	if !p.expectPeek(token.ASSIGN) {
 		return nil
 	}
@@ -456,7 +1014,11 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	value, err := strconv.ParseInt(p.curToken.Literal, 0, 64)
 
 	if err != nil {
-		msg := fmt.Sprintf("File: %s: Line %d: could not parse %q as integer", file.GetFileName(), p.curToken.Line, p.curToken.Literal)
+		msg := fmt.Sprintf("File: %s: Line %d: could not parse %q as integer", file.GetFileName(), p.curToken.Line+1, p.curToken.Literal)
+		if errors.Is(err, strconv.ErrRange) {
+			msg = fmt.Sprintf("File: %s: Line %d: integer literal %s overflows int64 (max %d)",
+				file.GetFileName(), p.curToken.Line+1, p.curToken.Literal, int64(math.MaxInt64))
+		}
 		p.errors = append(p.errors, msg)
 		return nil
 	}
@@ -465,6 +1027,10 @@ func (p *Parser) parseIntegerLiteral() ast.Expression {
 	return lit
 }
 
+func (p *Parser) parseIllegal() ast.Expression {
+	return nil
+}
+
 func (p *Parser) parseArrayLiteral() ast.Expression {
 	array := &ast.ArrayLiteral{Token: p.curToken}
 	array.Elements = p.parseExpressionList(token.RBRACKET)
@@ -491,9 +1057,39 @@ func (p *Parser) parseExpressionList(end token.TokenType) []ast.Expression {
 }
 
 func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
-	exp := &ast.IndexExpression{Token: p.curToken, Left: left}
+	tok := p.curToken
+
+	var start ast.Expression
+	if !p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		start = p.parseExpression(LOWEST)
+	}
+	if p.peekTokenIs(token.COLON) {
+		return p.parseSliceExpression(tok, left, start)
+	}
+
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+	return &ast.IndexExpression{Token: tok, Left: left, Index: start}
+}
+
+func (p *Parser) parseSliceExpression(tok token.Token, left, start ast.Expression) ast.Expression {
+	exp := &ast.SliceExpression{Token: tok, Left: left, Start: start}
+
 	p.nextToken()
-	exp.Index = p.parseExpression(LOWEST)
+	if !p.peekTokenIs(token.COLON) && !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		exp.Stop = p.parseExpression(LOWEST)
+	}
+	if p.peekTokenIs(token.COLON) {
+		p.nextToken()
+		if !p.peekTokenIs(token.RBRACKET) {
+			p.nextToken()
+			exp.Step = p.parseExpression(LOWEST)
+		}
+	}
+
 	if !p.expectPeek(token.RBRACKET) {
 		return nil
 	}
@@ -502,7 +1098,7 @@ func (p *Parser) parseIndexExpression(left ast.Expression) ast.Expression {
 
 func (p *Parser) parseHashLiteral() ast.Expression {
 	hash := &ast.HashLiteral{Token: p.curToken}
-	hash.Pairs = make(map[ast.Expression]ast.Expression)
+	hash.Pairs = []*ast.HashLiteralPair{}
 	for !p.peekTokenIs(token.RBRACE) {
 		p.nextToken()
 		key := p.parseExpression(LOWEST)
@@ -511,7 +1107,7 @@ func (p *Parser) parseHashLiteral() ast.Expression {
 		}
 		p.nextToken()
 		value := p.parseExpression(LOWEST)
-		hash.Pairs[key] = value
+		hash.Pairs = append(hash.Pairs, &ast.HashLiteralPair{Key: key, Value: value})
 		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
 			return nil
 		}
@@ -584,15 +1180,16 @@ func (p *Parser) parseSwitchStatement() ast.Expression {
 		tmp := &ast.CaseExpression{Token: p.curToken}
 
 		if p.curTokenIs(token.DEFAULT) {
-			tmp.Default = &ast.Boolean{Value: true}
+			tmp.Default = &ast.Boolean{Token: p.curToken, Value: true}
 
 		} else if p.curTokenIs(token.CASE) {
 			p.nextToken()
 
 			if p.curTokenIs(token.DEFAULT) {
-				tmp.Default = &ast.Boolean{Value: true}
+				tmp.Default = &ast.Boolean{Token: p.curToken, Value: true}
 			} else {
 				tmp.Expr = p.parseExpression(LOWEST)
+				tmp.Pattern = variantPatternOf(tmp.Expr)
 			}
 		}
 
@@ -622,7 +1219,7 @@ func (p *Parser) parseSwitchStatement() ast.Expression {
 
 	count := 0
 	for _, c := range expression.Choices {
-		if c.Default.Token.Type == token.TRUE {
+		if c.Default != nil {
 			count++
 		}
 	}
@@ -639,20 +1236,35 @@ func (p *Parser) parseForEach() ast.Expression {
 	expression := &ast.ForeachStatement{Token: p.curToken}
 
 	p.nextToken()
-	expression.Identifier = &ast.StringLiteral{Value: p.curToken.Literal}
+	target := p.parseBindingTarget()
+	if target == nil {
+		return nil
+	}
 
 	if p.peekTokenIs(token.COMMA) {
 		p.nextToken()
 
-		if !p.peekTokenIs(token.IDENTIFIER) {
-			p.errors = append(p.errors, fmt.Sprintf("second argument to foreach must be ident, got %v", p.peekToken))
+		index, ok := target.(*ast.Identifier)
+		if !ok {
+			p.errors = append(p.errors, fmt.Sprintf("first argument to foreach must be ident, got %s", target.String()))
+			return nil
+		}
+		if !p.peekTokenIs(token.IDENTIFIER) && !p.peekTokenIs(token.LBRACKET) && !p.peekTokenIs(token.LBRACE) {
+			p.errors = append(p.errors, fmt.Sprintf("second argument to foreach must be ident or pattern, got %v", p.peekToken))
 			return nil
 		}
 		p.nextToken()
 
-		expression.Index = expression.Identifier
-		expression.Identifier = &ast.StringLiteral{Value: p.curToken.Literal}
+		expression.Index = index
+		if target = p.parseBindingTarget(); target == nil {
+			return nil
+		}
+	}
 
+	if ident, ok := target.(*ast.Identifier); ok {
+		expression.Identifier = ident
+	} else {
+		expression.Pattern = target
 	}
 
 	if !p.expectPeek(token.IN) {
@@ -688,7 +1300,10 @@ func (p *Parser) parseFloatLiteral() ast.Expression {
 	flo := &ast.FloatLiteral{Token: p.curToken}
 	value, err := strconv.ParseFloat(p.curToken.Literal, 64)
 	if err != nil {
-		msg := fmt.Sprintf("could not parse %q as float", p.curToken.Literal)
+		msg := fmt.Sprintf("File: %s: Line %d: could not parse %q as float", file.GetFileName(), p.curToken.Line+1, p.curToken.Literal)
+		if errors.Is(err, strconv.ErrRange) {
+			msg = fmt.Sprintf("File: %s: Line %d: float literal %s is out of range for float64", file.GetFileName(), p.curToken.Line+1, p.curToken.Literal)
+		}
 		p.errors = append(p.errors, msg)
 		return nil
 	}
@@ -696,13 +1311,355 @@ func (p *Parser) parseFloatLiteral() ast.Expression {
 	return flo
 }
 
+func (p *Parser) parseDecimalLiteral() ast.Expression {
+	return &ast.DecimalLiteral{Token: p.curToken, Value: strings.TrimSuffix(p.curToken.Literal, "d")}
+}
+
 func (p *Parser) parseMethodCallExpression(obj ast.Expression) ast.Expression {
 	methodCall := &ast.ObjectCallExpression{Token: p.curToken, Object: obj}
 
+	if !isMemberName(p.peekToken) {
+		p.peekError(token.IDENTIFIER)
+		return nil
+	}
 	p.nextToken()
 	name := p.parseIdentifier()
+	if !p.peekTokenIs(token.LPAREN) {
+		return &ast.IndexExpression{Token: p.curToken, Left: obj, Index: &ast.StringLiteral{Token: p.curToken, Value: p.curToken.Literal}, Member: true}
+	}
 
 	p.nextToken()
 	methodCall.Call = p.parseCallExpression(name)
 	return methodCall
 }
+
+// isMemberName reports whether tok can name a method or member after a dot.
+// Keywords are allowed there, so xs.match() and e.const both parse.
+func isMemberName(tok token.Token) bool {
+	return tok.Type == token.IDENTIFIER || token.LookupIdentifier(tok.Literal) == tok.Type
+}
+
+func (p *Parser) parseEnumStatement() ast.Statement {
+	stmt := &ast.EnumStatement{Token: p.curToken}
+	if !p.expectPeek(token.IDENTIFIER) {
+		return nil
+	}
+	stmt.Name = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	seen := map[string]bool{}
+	for !p.peekTokenIs(token.RBRACE) {
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		variant := &ast.EnumVariant{Name: &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}}
+		if seen[variant.Name.Value] {
+			msg := fmt.Sprintf("File: %s: Line %d: duplicate variant `%s` in enum %s",
+				file.GetFileName(), p.curToken.Line+1, variant.Name.Value, stmt.Name.Value)
+			p.errors = append(p.errors, msg)
+			return nil
+		}
+		seen[variant.Name.Value] = true
+
+		if p.peekTokenIs(token.LPAREN) {
+			p.nextToken()
+			fields := map[string]bool{}
+			for !p.peekTokenIs(token.RPAREN) {
+				if !p.expectPeek(token.IDENTIFIER) {
+					return nil
+				}
+				if fields[p.curToken.Literal] {
+					msg := fmt.Sprintf("File: %s: Line %d: duplicate field `%s` in variant %s",
+						file.GetFileName(), p.curToken.Line+1, p.curToken.Literal, variant.Name.Value)
+					p.errors = append(p.errors, msg)
+					return nil
+				}
+				fields[p.curToken.Literal] = true
+				variant.Fields = append(variant.Fields, &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal})
+				if !p.peekTokenIs(token.RPAREN) && !p.expectPeek(token.COMMA) {
+					return nil
+				}
+			}
+			p.nextToken()
+			if len(variant.Fields) == 0 {
+				msg := fmt.Sprintf("File: %s: Line %d: variant %s needs at least one field, drop the parentheses for a plain variant",
+					file.GetFileName(), p.curToken.Line+1, variant.Name.Value)
+				p.errors = append(p.errors, msg)
+				return nil
+			}
+		}
+		stmt.Variants = append(stmt.Variants, variant)
+
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	p.nextToken()
+
+	if len(stmt.Variants) == 0 {
+		msg := fmt.Sprintf("File: %s: Line %d: enum %s has no variants",
+			file.GetFileName(), p.curToken.Line+1, stmt.Name.Value)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+	if p.peekTokenIs(token.SEMICOLON) {
+		p.nextToken()
+	}
+	return stmt
+}
+
+func (p *Parser) parseMatchExpression() ast.Expression {
+	expression := &ast.MatchExpression{Token: p.curToken}
+
+	if !p.expectPeek(token.LPAREN) {
+		return nil
+	}
+	p.nextToken()
+	expression.Subject = p.parseExpression(LOWEST)
+	if !p.expectPeek(token.RPAREN) || !p.expectPeek(token.LBRACE) {
+		return nil
+	}
+
+	for !p.peekTokenIs(token.RBRACE) {
+		if p.peekTokenIs(token.EOF) {
+			msg := fmt.Sprintf("File: %s: Line %d: unterminated match expression", file.GetFileName(), expression.Token.Line+1)
+			p.errors = append(p.errors, msg)
+			return nil
+		}
+		p.nextToken()
+
+		arm := &ast.MatchArm{Pattern: p.parseMatchPattern()}
+		if arm.Pattern == nil {
+			return nil
+		}
+		if p.peekTokenIs(token.IF) {
+			p.nextToken()
+			p.nextToken()
+			arm.Guard = p.parseExpression(LAMBDA)
+		}
+		if !p.expectPeek(token.ARROW) {
+			return nil
+		}
+
+		p.nextToken()
+		if p.curTokenIs(token.LBRACE) {
+			arm.Body = p.parseBlockStatement()
+		} else {
+			body := &ast.ExpressionStatement{Token: p.curToken, Expression: p.parseExpression(LOWEST)}
+			arm.Body = &ast.BlockStatement{Token: body.Token, Statements: []ast.Statement{body}}
+		}
+		expression.Arms = append(expression.Arms, arm)
+
+		if p.peekTokenIs(token.COMMA) {
+			p.nextToken()
+		}
+	}
+	p.nextToken()
+
+	return expression
+}
+
+func (p *Parser) parseMatchPattern() ast.Expression {
+	switch p.curToken.Type {
+	case token.IDENTIFIER:
+		name := &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		if p.peekTokenIs(token.DOT) {
+			return p.parseVariantPattern(name)
+		}
+		if !p.peekTokenIs(token.COLON) {
+			return name
+		}
+		p.nextToken()
+		if !p.expectPeek(token.IDENTIFIER) {
+			return nil
+		}
+		return &ast.TypePattern{Token: name.Token, Name: name, TypeName: p.curToken.Literal}
+	case token.LBRACKET:
+		return p.parseArrayMatchPattern()
+	case token.LBRACE:
+		return p.parseHashMatchPattern()
+	}
+
+	tok := p.curToken
+	low := p.parseExpression(RANGE)
+	if !isLiteralPattern(low) {
+		msg := fmt.Sprintf("File: %s: Line %d: invalid pattern %s", file.GetFileName(), tok.Line+1, tok.Literal)
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+	if !p.peekTokenIs(token.DOT_DOT) && !p.peekTokenIs(token.DOT_DOT_EQ) {
+		return &ast.LiteralPattern{Token: tok, Value: low}
+	}
+
+	p.nextToken()
+	pattern := &ast.RangePattern{Token: tok, Low: low, Inclusive: p.curTokenIs(token.DOT_DOT_EQ)}
+	p.nextToken()
+	pattern.High = p.parseExpression(RANGE)
+	if !isLiteralPattern(pattern.High) {
+		msg := fmt.Sprintf("File: %s: Line %d: invalid upper bound in range pattern %s",
+			file.GetFileName(), tok.Line+1, pattern.String())
+		p.errors = append(p.errors, msg)
+		return nil
+	}
+	return pattern
+}
+
+func (p *Parser) parseVariantPattern(enum *ast.Identifier) ast.Expression {
+	pattern := &ast.VariantPattern{Token: enum.Token, Enum: enum}
+	p.nextToken()
+	if !isMemberName(p.peekToken) {
+		p.peekError(token.IDENTIFIER)
+		return nil
+	}
+	p.nextToken()
+	pattern.Variant = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+	if !p.peekTokenIs(token.LPAREN) {
+		return pattern
+	}
+
+	p.nextToken()
+	pattern.Payload = true
+	for !p.peekTokenIs(token.RPAREN) {
+		p.nextToken()
+		field := p.parseMatchPattern()
+		if field == nil {
+			return nil
+		}
+		pattern.Fields = append(pattern.Fields, field)
+		if !p.peekTokenIs(token.RPAREN) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	if !p.expectPeek(token.RPAREN) {
+		return nil
+	}
+	return pattern
+}
+
+// variantPatternOf reads a switch case such as Shape.Rect(w, 0) as the
+// pattern match would parse, or returns nil when the case has another shape.
+func variantPatternOf(exp ast.Expression) *ast.VariantPattern {
+	call, ok := exp.(*ast.ObjectCallExpression)
+	if !ok || call.Optional {
+		return nil
+	}
+	enum, ok := call.Object.(*ast.Identifier)
+	if !ok {
+		return nil
+	}
+	fn, ok := call.Call.(*ast.CallExpression)
+	if !ok {
+		return nil
+	}
+	variant, ok := fn.Function.(*ast.Identifier)
+	if !ok {
+		return nil
+	}
+
+	pattern := &ast.VariantPattern{Token: enum.Token, Enum: enum, Variant: variant, Payload: true}
+	for _, arg := range fn.Arguments {
+		var field ast.Expression
+		switch arg := arg.(type) {
+		case *ast.Identifier:
+			field = arg
+		case *ast.ObjectCallExpression:
+			if nested := variantPatternOf(arg); nested != nil {
+				field = nested
+			}
+		case *ast.IndexExpression:
+			enum, isEnum := arg.Left.(*ast.Identifier)
+			name, isName := arg.Index.(*ast.StringLiteral)
+			if isEnum && isName && arg.Member && !arg.Optional {
+				variant := &ast.Identifier{Token: name.Token, Value: name.Value}
+				field = &ast.VariantPattern{Token: enum.Token, Enum: enum, Variant: variant}
+			}
+		default:
+			if isLiteralPattern(arg) {
+				field = &ast.LiteralPattern{Token: fn.Token, Value: arg}
+			}
+		}
+		if field == nil {
+			return nil
+		}
+		pattern.Fields = append(pattern.Fields, field)
+	}
+	return pattern
+}
+
+func isLiteralPattern(exp ast.Expression) bool {
+	switch exp := exp.(type) {
+	case *ast.IntegerLiteral, *ast.FloatLiteral, *ast.DecimalLiteral, *ast.StringLiteral, *ast.Boolean, *ast.NullLiteral:
+		return true
+	case *ast.PrefixExpression:
+		return exp.Operator == "-" && isLiteralPattern(exp.Right)
+	default:
+		return false
+	}
+}
+
+func (p *Parser) parseArrayMatchPattern() ast.Expression {
+	pattern := &ast.ArrayPattern{Token: p.curToken}
+	for !p.peekTokenIs(token.RBRACKET) {
+		p.nextToken()
+		if p.curTokenIs(token.ELLIPSIS) {
+			if pattern.Rest = p.parsePatternRest(); pattern.Rest == nil {
+				return nil
+			}
+			break
+		}
+		element := p.parseMatchPattern()
+		if element == nil {
+			return nil
+		}
+		pattern.Elements = append(pattern.Elements, element)
+		if !p.peekTokenIs(token.RBRACKET) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	if !p.expectPeek(token.RBRACKET) {
+		return nil
+	}
+	return pattern
+}
+
+func (p *Parser) parseHashMatchPattern() ast.Expression {
+	pattern := &ast.HashPattern{Token: p.curToken}
+	for !p.peekTokenIs(token.RBRACE) {
+		p.nextToken()
+		if p.curTokenIs(token.ELLIPSIS) {
+			if pattern.Rest = p.parsePatternRest(); pattern.Rest == nil {
+				return nil
+			}
+			break
+		}
+		if !p.curTokenIs(token.IDENTIFIER) && !p.curTokenIs(token.STRING) {
+			msg := fmt.Sprintf("File: %s: Line %d: expected key in hash pattern, got %s",
+				file.GetFileName(), p.curToken.Line+1, p.curToken.Type)
+			p.errors = append(p.errors, msg)
+			return nil
+		}
+		pair := &ast.HashPatternPair{Key: p.curToken.Literal}
+		if p.peekTokenIs(token.COLON) {
+			p.nextToken()
+			p.nextToken()
+			if pair.Target = p.parseMatchPattern(); pair.Target == nil {
+				return nil
+			}
+		} else if p.curTokenIs(token.IDENTIFIER) {
+			pair.Target = &ast.Identifier{Token: p.curToken, Value: p.curToken.Literal}
+		} else {
+			p.peekError(token.COLON)
+			return nil
+		}
+		pattern.Pairs = append(pattern.Pairs, pair)
+		if !p.peekTokenIs(token.RBRACE) && !p.expectPeek(token.COMMA) {
+			return nil
+		}
+	}
+	if !p.expectPeek(token.RBRACE) {
+		return nil
+	}
+	return pattern
+}
@@ -0,0 +1,549 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/lexer"
+)
+
+func parseProgram(t *testing.T, input string) *ast.Program {
+	t.Helper()
+	p := New(lexer.New(input))
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) != 0 {
+		t.Fatalf("%q: unexpected parser errors: %v", input, errs)
+	}
+	return program
+}
+
+func parseErrors(input string) []string {
+	p := New(lexer.New(input))
+	p.ParseProgram()
+	return p.Errors()
+}
+
+func expectParseError(t *testing.T, input, want string) {
+	t.Helper()
+	errs := parseErrors(input)
+	for _, err := range errs {
+		if strings.Contains(err, want) {
+			return
+		}
+	}
+	t.Errorf("%q: got errors %v, want one containing %q", input, errs, want)
+}
+
+func parseExpressionStatement(t *testing.T, input string) ast.Expression {
+	t.Helper()
+	program := parseProgram(t, input)
+	if len(program.Statements) != 1 {
+		t.Fatalf("%q: got %d statements, want 1", input, len(program.Statements))
+	}
+	stmt, ok := program.Statements[0].(*ast.ExpressionStatement)
+	if !ok {
+		t.Fatalf("%q: got %T, want *ast.ExpressionStatement", input, program.Statements[0])
+	}
+	return stmt.Expression
+}
+
+func TestNumericLiterals(t *testing.T) {
+	integers := []struct {
+		input string
+		value int64
+	}{
+		{"0xFF", 255},
+		{"0o755", 493},
+		{"0b1010", 10},
+		{"1_000_000", 1000000},
+		{"0x_7FFF_FFFF_FFFF_FFFF", 9223372036854775807},
+	}
+	for _, tt := range integers {
+		lit, ok := parseExpressionStatement(t, tt.input).(*ast.IntegerLiteral)
+		if !ok || lit.Value != tt.value {
+			t.Errorf("%q: got %#v, want integer %d", tt.input, lit, tt.value)
+		}
+	}
+
+	floats := []struct {
+		input string
+		value float64
+	}{
+		{"6.02e23", 6.02e23},
+		{"1e-3", 0.001},
+		{"1_000.5", 1000.5},
+	}
+	for _, tt := range floats {
+		lit, ok := parseExpressionStatement(t, tt.input).(*ast.FloatLiteral)
+		if !ok || lit.Value != tt.value {
+			t.Errorf("%q: got %#v, want float %g", tt.input, lit, tt.value)
+		}
+	}
+}
+
+func TestNumericLiteralErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		err   string
+	}{
+		{"99999999999999999999", "Line 1: integer literal 99999999999999999999 overflows int64"},
+		{"0x8000000000000000", "Line 1: integer literal 0x8000000000000000 overflows int64"},
+		{"1e999", "Line 1: float literal 1e999 is out of range for float64"},
+		{"\n0b102", "Line 2: invalid digit '2' in binary literal 0b102"},
+		{"0x", "Line 1: missing digits in hex literal 0x"},
+	}
+	for _, tt := range tests {
+		expectParseError(t, tt.input, tt.err)
+	}
+
+	if errs := parseErrors("var x = 0b12;"); len(errs) != 1 {
+		t.Errorf("got errors %v, want only the lexer error", errs)
+	}
+}
+
+func TestDecimalLiterals(t *testing.T) {
+	tests := []struct {
+		input string
+		value string
+	}{
+		{"12.50d", "12.50"},
+		{"3d", "3"},
+		{"1_000.25d", "1_000.25"},
+		{"1.5e2d", "1.5e2"},
+	}
+	for _, tt := range tests {
+		lit, ok := parseExpressionStatement(t, tt.input).(*ast.DecimalLiteral)
+		if !ok || lit.Value != tt.value || lit.String() != tt.input {
+			t.Errorf("%q: got %#v, want decimal %q", tt.input, lit, tt.value)
+		}
+	}
+}
+
+func TestBitwisePrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a | b & c", "(a | (b & c))"},
+		{"a ~ b & c", "(a ~ (b & c))"},
+		{"a | b ~ c", "(a | (b ~ c))"},
+		{"a & b << 2", "(a & (b << 2))"},
+		{"1 << 2 + 3", "(1 << (2 + 3))"},
+		{"a >> b * c", "(a >> (b * c))"},
+		{"~a & b", "((~a) & b)"},
+		{"a == b | c", "(a == (b | c))"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestHashLiteralKeepsSourceOrder(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`{"b": 1, "a": 2}`, "{b:1, a:2}"},
+		{`{3: x, 1: y, 2: z}`, "{3:x, 1:y, 2:z}"},
+		{`{}`, "{}"},
+	}
+	for _, tt := range tests {
+		hash, ok := parseExpressionStatement(t, tt.input).(*ast.HashLiteral)
+		if !ok {
+			t.Fatalf("%q: got %T, want *ast.HashLiteral", tt.input, hash)
+		}
+		if got := hash.String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestSliceExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"xs[1:3]", "(xs[1:3])"},
+		{"xs[:2]", "(xs[:2])"},
+		{"xs[2:]", "(xs[2:])"},
+		{"xs[:]", "(xs[:])"},
+		{"xs[::2]", "(xs[::2])"},
+		{"xs[1::-1]", "(xs[1::(-1)])"},
+		{"xs[-1:a + 1:2]", "(xs[(-1):(a + 1):2])"},
+		{"xs?.[1:]", "(xs?.[1:])"},
+	}
+	for _, tt := range tests {
+		slice, ok := parseExpressionStatement(t, tt.input).(*ast.SliceExpression)
+		if !ok {
+			t.Fatalf("%q: got %T, want *ast.SliceExpression", tt.input, slice)
+		}
+		if got := slice.String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+	if _, ok := parseExpressionStatement(t, "xs[-1]").(*ast.IndexExpression); !ok {
+		t.Errorf("xs[-1] should parse as an index expression")
+	}
+	expectParseError(t, "xs[1:2", "expected next token to be ]")
+}
+
+func TestRangePrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"0..5", "(0 .. 5)"},
+		{"1..=n", "(1 ..= n)"},
+		{"a + 1..b * 2", "((a + 1) .. (b * 2))"},
+		{"0..n == r", "((0 .. n) == r)"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestGeneratorFunctions(t *testing.T) {
+	tests := []struct {
+		input       string
+		isGenerator bool
+	}{
+		{"func() { yield 1 }", true},
+		{"func() { if (x) { yield x } }", true},
+		{"func() { 1 }", false},
+		{"func() { var f = func() { yield 1 }; f }", false},
+	}
+	for _, tt := range tests {
+		fn, ok := parseExpressionStatement(t, tt.input).(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("%q: got %T, want *ast.FunctionLiteral", tt.input, fn)
+		}
+		if fn.IsGenerator != tt.isGenerator {
+			t.Errorf("%q: got IsGenerator=%t, want %t", tt.input, fn.IsGenerator, tt.isGenerator)
+		}
+	}
+	expectParseError(t, "yield 1", "yield outside of a function")
+}
+
+func TestDestructuringDeclarations(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"var [a, b] = xs", "var [a, b] = xs;"},
+		{"var [a, ...rest] = xs", "var [a, ...rest] = xs;"},
+		{"var [[a, b], c] = xs", "var [[a, b], c] = xs;"},
+		{"var {name, age: years} = person", "var {name, age: years} = person;"},
+		{"var {name, ...others} = person", "var {name, ...others} = person;"},
+		{"const [a, _] = xs", "const [a, _] = xs;"},
+		{"mut [a, b] = [b, a]", "mut [a, b] = [b, a];"},
+	}
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+		stmt, ok := program.Statements[0].(*ast.AssignStatement)
+		if !ok || stmt.Pattern == nil {
+			t.Fatalf("%q: got %#v, want an assign statement with a pattern", tt.input, program.Statements[0])
+		}
+		if got := stmt.String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+	expectParseError(t, "var [a, 1] = xs", "expected identifier or destructuring pattern, got INT")
+}
+
+func TestFunctionParameters(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"func() { 1 }", []string{}},
+		{"func(a, b) { a }", []string{"a", "b"}},
+		{"func(a, b = 2) { a }", []string{"a", "b = 2"}},
+		{"func(a, b = a * 2) { a }", []string{"a", "b = (a * 2)"}},
+		{"func(first, ...rest) { rest }", []string{"first", "...rest"}},
+		{"func([a, b], {c}) { a }", []string{"[a, b]", "{c}"}},
+	}
+	for _, tt := range tests {
+		fn, ok := parseExpressionStatement(t, tt.input).(*ast.FunctionLiteral)
+		if !ok {
+			t.Fatalf("%q: got %T, want *ast.FunctionLiteral", tt.input, fn)
+		}
+		got := []string{}
+		for _, param := range fn.Parameters {
+			got = append(got, param.String())
+		}
+		if strings.Join(got, "|") != strings.Join(tt.expected, "|") {
+			t.Errorf("%q: got parameters %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+	expectParseError(t, "func(...a, b) { a }", "rest parameter ...a must be the last parameter")
+}
+
+func TestSpreadArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"f(...xs)", "f(...xs)"},
+		{"f(1, ...xs, 2)", "f(1, ...xs, 2)"},
+		{"f(...g(x))", "f(...g(x))"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestNamedArguments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`connect(host: "db", port: 5432)`, "connect(host: db, port: 5432)"},
+		{`connect("db", timeout: 1 + 2)`, "connect(db, timeout: (1 + 2))"},
+		{`print(a, b, sep: ", ")`, "print(a, b, sep: , )"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+	expectParseError(t, `connect(host: "db", 5432)`, "positional argument follows named argument")
+}
+
+func TestArrowFunctions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x => x * 2", "(x) => (x * 2)"},
+		{"(x) => x * 2", "(x) => (x * 2)"},
+		{"() => 42", "() => 42"},
+		{"(a, b = 10) => a + b", "(a, b = 10) => (a + b)"},
+		{"(first, ...rest) => rest", "(first, ...rest) => rest"},
+		{"([a, b]) => a", "([a, b]) => a"},
+		{"([a, ...rest]) => rest", "([a, ...rest]) => rest"},
+		{"({name, age: years}) => name", "({name, age: years}) => name"},
+		{"({name, ...others}) => others", "({name, ...others}) => others"},
+		{"([{x}, [y, ...zs]]) => x", "([{x}, [y, ...zs]]) => x"},
+		{"a => b => a + b", "(a) => (b) => (a + b)"},
+		{"x => { return x }", "(x) => return x;"},
+	}
+	for _, tt := range tests {
+		fn, ok := parseExpressionStatement(t, tt.input).(*ast.FunctionLiteral)
+		if !ok || !fn.IsArrow {
+			t.Fatalf("%q: got %T, want an arrow function", tt.input, fn)
+		}
+		if got := fn.String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestArrowFunctionsInExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"xs.map(x => x * 2)", "xs.map((x) => (x * 2))"},
+		{`{"f": x => x}`, "{f:(x) => x}"},
+		{"(1 + 2) * 3", "((1 + 2) * 3)"},
+		{"(a)", "a"},
+		{"([1, 2])[0]", "([1, 2][0])"},
+		{`({"a": 1})["a"]`, "({a:1}[a])"},
+		{"(x => x)(5)", "(x) => x(5)"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestArrowFunctionErrors(t *testing.T) {
+	expectParseError(t, "(a, b)", "expected next token to be =>")
+	expectParseError(t, "()", "expected next token to be =>")
+	expectParseError(t, "({x})", "expected next token to be =>")
+	expectParseError(t, "(a + 1) => a", "invalid arrow function parameter (a + 1)")
+	expectParseError(t, "(...rest, a) => a", "rest parameter ...rest must be the last parameter")
+	expectParseError(t, "({1: x}) => x", "invalid arrow function parameter 1")
+}
+
+func TestPipelineExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"x |> f", "(x |> f)"},
+		{"x |> f |> g", "((x |> f) |> g)"},
+		{"x |> f(a)", "(x |> f(a))"},
+		{"x |> f(a, _)", "(x |> f(a, _))"},
+		{"a + b |> f", "((a + b) |> f)"},
+		{"x |> f ?? y", "(x |> (f ?? y))"},
+		{"c ? x |> f : y", "(c ? (x |> f) : y)"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestOptionalChaining(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a?.b", "(a?.b)"},
+		{"a?.b.c", "((a?.b).c)"},
+		{"a?.[0].x", "((a?.[0]).x)"},
+		{"a?.b()", "a?.b()"},
+		{"a?.m().n(1)", "a?.m().n(1)"},
+		{"a?.b ?? c", "((a?.b) ?? c)"},
+		{"a ?? b ?? c", "((a ?? b) ?? c)"},
+		{"a ? b : c ? d : e", "(a ? b : (c ? d : e))"},
+		{"a == null ? 1 : 2", "((a == null) ? 1 : 2)"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMatchExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"match (x) { 1 => a, _ => b }", "match (x) {1 => a, _ => b}"},
+		{"match (x) { 1..5 => a, 5..=9 => b }", "match (x) {1..5 => a, 5..=9 => b}"},
+		{"match (x) { n: INTEGER => n }", "match (x) {n: INTEGER => n}"},
+		{"match (x) { [a, ...rest] => a }", "match (x) {[a, ...rest] => a}"},
+		{"match (x) { n if n > 1 => n }", "match (x) {n if (n > 1) => n}"},
+		{"match (x) { n if n > 1 && n < 5 => n }", "match (x) {n if ((n > 1) && (n < 5)) => n}"},
+		{"match (x) { n if n ?? false => n }", "match (x) {n if (n ?? false) => n}"},
+		{"match (x) { n if xs.any(y => y == n) => n }", "match (x) {n if xs.any((y) => (y == n)) => n}"},
+		{"match (x) { n if n > 1 => { n } }", "match (x) {n if (n > 1) => n}"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestMatchErrors(t *testing.T) {
+	expectParseError(t, "match (x) { 1 => a", "File: : Line 1: unterminated match expression")
+	expectParseError(t, "var x = 1;\nmatch (x) { 1 => a", "File: : Line 2: unterminated match expression")
+	expectParseError(t, "match (x) { !y => a }", "invalid pattern !")
+	expectParseError(t, "match (x) { 1..y => a }", "invalid upper bound in range pattern")
+	expectParseError(t, "match (x) { n if n > 1 a }", "expected next token to be =>")
+}
+
+func TestMemberExpressions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"a.b", "(a.b)"},
+		{"a.b.c", "((a.b).c)"},
+		{"a.b()", "a.b()"},
+		{"xs.match(1)", "xs.match(1)"},
+		{"xs.enum()", "xs.enum()"},
+		{"e.const", "(e.const)"},
+		{"e?.yield", "(e?.yield)"},
+		{"g?.if()", "g?.if()"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+	expectParseError(t, "xs.1", "expected next token to be IDENTIFIER, got INT instead")
+}
+
+func TestVariantPatterns(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"match (x) { Color.Red => 1 }", "match (x) {Color.Red => 1}"},
+		{"match (x) { Shape.Rect(w, _) => w }", "match (x) {Shape.Rect(w, _) => w}"},
+		{"match (x) { Opt.Some(Shape.Circle(r)) => r }", "match (x) {Opt.Some(Shape.Circle(r)) => r}"},
+		{"match (x) { Opt.Some(1..3) => 1 }", "match (x) {Opt.Some(1..3) => 1}"},
+		{"match (x) { [Opt.None, n: INTEGER] => n }", "match (x) {[Opt.None, n: INTEGER] => n}"},
+		{"match (x) { Opt.Some(n) if n > 1 => n }", "match (x) {Opt.Some(n) if (n > 1) => n}"},
+	}
+	for _, tt := range tests {
+		if got := parseExpressionStatement(t, tt.input).String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+	expectParseError(t, "match (x) { Opt.(n) => n }", "expected next token to be IDENTIFIER")
+	expectParseError(t, "match (x) { Opt.Some(n => n }", "expected next token to be ,")
+}
+
+func TestSwitchVariantPatterns(t *testing.T) {
+	tests := []struct {
+		input   string
+		pattern string
+	}{
+		{"switch (x) { case Shape.Rect(w, _) { w } }", "Shape.Rect(w, _)"},
+		{"switch (x) { case Opt.Some(Color.Red) { 1 } }", "Opt.Some(Color.Red)"},
+		{"switch (x) { case Opt.Some(Shape.Circle(-1)) { 1 } }", "Opt.Some(Shape.Circle((-1)))"},
+		{"switch (x) { case Color.Red { 1 } }", ""},
+		{"switch (x) { case xs.find(y + 1) { 1 } }", ""},
+		{"switch (x) { case xs?.find(y) { 1 } }", ""},
+	}
+	for _, tt := range tests {
+		choice := parseExpressionStatement(t, tt.input).(*ast.SwitchExpression).Choices[0]
+		got := ""
+		if choice.Pattern != nil {
+			got = choice.Pattern.String()
+		}
+		if got != tt.pattern {
+			t.Errorf("%q: got pattern %q, want %q", tt.input, got, tt.pattern)
+		}
+	}
+}
+
+func TestConstStatements(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"const x = 1;", "const x = 1;"},
+		{"const [a, b] = xs;", "const [a, b] = xs;"},
+		{"const cfg = freeze({\"a\": 1});", "const cfg = freeze({a:1});"},
+	}
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+		if got := program.String(); got != tt.expected {
+			t.Errorf("%q: got %s, want %s", tt.input, got, tt.expected)
+		}
+	}
+	expectParseError(t, "const = 1;", "expected next token to be IDENTIFIER, got = instead")
+}
+
+func TestIdentifiersStartUnresolved(t *testing.T) {
+	tests := []string{
+		"x",
+		"var x = y;",
+		"func(a) { return a; }",
+		"match (x) { n => n }",
+	}
+	for _, input := range tests {
+		ast.Modify(parseProgram(t, input), func(node ast.Node) ast.Node {
+			if ident, ok := node.(*ast.Identifier); ok && (ident.Resolved || ident.Depth != 0 || ident.Slot != 0) {
+				t.Errorf("%q: %s starts resolved at (%d, %d)", input, ident.Value, ident.Depth, ident.Slot)
+			}
+			return node
+		})
+	}
+}
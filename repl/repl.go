@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/evaluator"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/file"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/lexer"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
 	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/parser"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/resolver"
 )
 
 const PROMPT = ">>> "
@@ -19,6 +21,7 @@ func Start(in io.Reader, out io.Writer) {
 	scanner := bufio.NewScanner(in)
 	env := object.NewEnvironment()
 	macroEnv := object.NewEnvironment()
+	r := resolver.New()
 
 	for {
 		fmt.Print(PROMPT)
@@ -39,8 +42,22 @@ func Start(in io.Reader, out io.Writer) {
 		}
 
 		evaluator.DefineMacros(program, macroEnv)
-		expanded := evaluator.ExpandMacros(program, macroEnv)
+		expanded := evaluator.ExpandMacros(program, macroEnv).(*ast.Program)
 
+		state := r.Save()
+		r.Resolve(expanded)
+		if len(r.Errors()) != 0 {
+			r.Restore(state, nil)
+			evaluator.PrintParserErrors(out, r.Errors())
+			continue
+		}
+
+		// A statement that fails at runtime leaves its names undefined, so
+		// only keep the declarations that actually reached env.
 		evaluator.Eval(expanded, env)
+		r.Restore(state, func(name string) bool {
+			_, ok := env.Get(name)
+			return ok
+		})
 	}
 }
@@ -0,0 +1,527 @@
+package resolver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/evaluator"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/file"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/lexer"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/parser"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/token"
+)
+
+type binding struct {
+	slot     int
+	declared bool
+	constant bool
+	enum     bool
+}
+
+type scope struct {
+	bindings map[string]*binding
+	slots    int
+	named    bool
+	function int
+	outer    *scope
+}
+
+type Resolver struct {
+	scope     *scope
+	functions int
+	loading   map[string]bool
+	fileName  string
+	errors    []string
+}
+
+func New() *Resolver {
+	return &Resolver{
+		scope:   &scope{bindings: make(map[string]*binding), named: true},
+		loading: make(map[string]bool),
+	}
+}
+
+func (r *Resolver) Resolve(program *ast.Program) {
+	r.errors = []string{}
+	r.fileName = file.GetFileName()
+	r.loading[filepath.Clean(r.fileName)] = true
+	defer delete(r.loading, filepath.Clean(r.fileName))
+
+	r.hoist(program.Statements, false)
+	r.resolveStatements(program.Statements)
+}
+
+// State is a copy of the top-level bindings, taken so the REPL can undo a
+// line that failed to resolve or to run.
+type State map[string]binding
+
+func (r *Resolver) Save() State {
+	state := make(State, len(r.scope.bindings))
+	for name, b := range r.scope.bindings {
+		state[name] = *b
+	}
+	return state
+}
+
+// Restore puts the top-level bindings back to a saved state. Names declared
+// since then are dropped unless keep reports that they exist after all.
+func (r *Resolver) Restore(state State, keep func(name string) bool) {
+	for name, b := range r.scope.bindings {
+		if saved, ok := state[name]; ok {
+			*b = saved
+		} else if keep == nil || !keep(name) {
+			delete(r.scope.bindings, name)
+		}
+	}
+}
+
+func (r *Resolver) Errors() []string {
+	return r.errors
+}
+
+func (r *Resolver) errorf(tok token.Token, format string, args ...interface{}) {
+	msg := fmt.Sprintf("File: %s: Line %d: %s", r.fileName, tok.Line+1, fmt.Sprintf(format, args...))
+	r.errors = append(r.errors, msg)
+}
+
+func (r *Resolver) pushScope(function bool) {
+	s := &scope{bindings: make(map[string]*binding), function: r.scope.function, outer: r.scope}
+	if function {
+		r.functions++
+		s.function = r.functions
+	}
+	r.scope = s
+}
+
+func (r *Resolver) popScope() {
+	r.scope = r.scope.outer
+}
+
+func (r *Resolver) reserve(name string, named bool) *binding {
+	if b, ok := r.scope.bindings[name]; ok {
+		return b
+	}
+	b := &binding{slot: -1}
+	if !named && !r.scope.named {
+		b.slot = r.scope.slots
+		r.scope.slots++
+	}
+	r.scope.bindings[name] = b
+	return b
+}
+
+func (r *Resolver) find(name string) (*binding, *scope, int) {
+	depth := 0
+	for s := r.scope; s != nil; s = s.outer {
+		if b, ok := s.bindings[name]; ok {
+			return b, s, depth
+		}
+		depth++
+	}
+	return nil, nil, 0
+}
+
+func (r *Resolver) isDeclared(name string) bool {
+	for s := r.scope; s != nil; s = s.outer {
+		if b, ok := s.bindings[name]; ok && b.declared {
+			return true
+		}
+	}
+	return false
+}
+
+func bind(ident *ast.Identifier, depth int, b *binding) {
+	if b.slot < 0 {
+		ident.Resolved = false
+		return
+	}
+	ident.Resolved = true
+	ident.Depth = depth
+	ident.Slot = b.slot
+}
+
+func (r *Resolver) declare(ident *ast.Identifier, constant bool) *binding {
+	if r.isDeclared(ident.Value) {
+		r.errorf(ident.Token, "Variable `%s` already defined", ident.Value)
+	}
+	b := r.reserve(ident.Value, false)
+	b.declared = true
+	b.constant = constant
+	bind(ident, 0, b)
+	return b
+}
+
+func (r *Resolver) define(ident *ast.Identifier) {
+	if b, ok := r.scope.bindings[ident.Value]; ok && b.declared {
+		r.errorf(ident.Token, "Variable `%s` bound more than once", ident.Value)
+	}
+	b := r.reserve(ident.Value, false)
+	b.declared = true
+	bind(ident, 0, b)
+}
+
+func (r *Resolver) defineNames(pattern ast.Expression) {
+	for _, ident := range patternNames(pattern, nil) {
+		r.define(ident)
+	}
+}
+
+func (r *Resolver) resolveReference(ident *ast.Identifier) {
+	b, s, depth := r.find(ident.Value)
+	if b == nil {
+		if !evaluator.IsBuiltin(ident.Value) {
+			r.errorf(ident.Token, "identifier not found: %s", ident.Value)
+		}
+		ident.Resolved = false
+		return
+	}
+	if !b.declared && s.function == r.scope.function {
+		r.errorf(ident.Token, "`%s` used before its declaration", ident.Value)
+	}
+	bind(ident, depth, b)
+}
+
+func (r *Resolver) resolveAssignment(ident *ast.Identifier) {
+	b, s, depth := r.find(ident.Value)
+	switch {
+	case b == nil, !b.declared && s.function == r.scope.function:
+		r.errorf(ident.Token, "Variable `%s` not defined", ident.Value)
+	case b.constant:
+		r.errorf(ident.Token, "Cannot assign to constant `%s`", ident.Value)
+	}
+	if b != nil {
+		bind(ident, depth, b)
+	}
+}
+
+func patternNames(pattern ast.Expression, names []*ast.Identifier) []*ast.Identifier {
+	switch pattern := pattern.(type) {
+	case *ast.Identifier:
+		if pattern.Value != "_" {
+			names = append(names, pattern)
+		}
+	case *ast.TypePattern:
+		names = patternNames(pattern.Name, names)
+	case *ast.VariantPattern:
+		for _, field := range pattern.Fields {
+			names = patternNames(field, names)
+		}
+	case *ast.ArrayPattern:
+		for _, element := range pattern.Elements {
+			names = patternNames(element, names)
+		}
+		if pattern.Rest != nil {
+			names = patternNames(pattern.Rest, names)
+		}
+	case *ast.HashPattern:
+		for _, pair := range pattern.Pairs {
+			names = patternNames(pair.Target, names)
+		}
+		if pattern.Rest != nil {
+			names = patternNames(pattern.Rest, names)
+		}
+	}
+	return names
+}
+
+func assignTargets(stmt *ast.AssignStatement) []*ast.Identifier {
+	if stmt.Pattern != nil {
+		return patternNames(stmt.Pattern, nil)
+	}
+	return []*ast.Identifier{stmt.Name}
+}
+
+func (r *Resolver) hoist(statements []ast.Statement, named bool) {
+	for _, statement := range statements {
+		switch statement := statement.(type) {
+		case *ast.AssignStatement:
+			if statement.Token.Type != token.VAR && statement.Token.Type != token.CONST {
+				continue
+			}
+			for _, ident := range assignTargets(statement) {
+				r.reserve(ident.Value, named)
+			}
+		case *ast.EnumStatement:
+			r.reserve(statement.Name.Value, named)
+		case *ast.ImportStatement:
+			r.hoistModule(statement)
+		}
+	}
+}
+
+func (r *Resolver) hoistModule(is *ast.ImportStatement) {
+	path := filepath.Clean(is.Path.Value)
+	if r.loading[path] {
+		r.errorf(is.Token, "import cycle through %s", is.Path.Value)
+		return
+	}
+
+	contents, err := os.ReadFile(is.Path.Value)
+	if err != nil {
+		r.errorf(is.Token, "could not read module %s", is.Path.Value)
+		return
+	}
+
+	fileName := r.fileName
+	file.SetFileName(is.Path.Value)
+	p := parser.New(lexer.New(string(contents)))
+	program := p.ParseProgram()
+	file.SetFileName(fileName)
+	if len(p.Errors()) != 0 {
+		r.errors = append(r.errors, p.Errors()...)
+		return
+	}
+
+	r.loading[path] = true
+	r.hoist(program.Statements, true)
+	delete(r.loading, path)
+	is.Program = program
+}
+
+func (r *Resolver) resolveStatements(statements []ast.Statement) {
+	for _, statement := range statements {
+		r.resolve(statement)
+	}
+}
+
+func (r *Resolver) resolveBlock(block *ast.BlockStatement) {
+	r.pushScope(false)
+	r.hoist(block.Statements, false)
+	r.resolveStatements(block.Statements)
+	r.popScope()
+}
+
+func (r *Resolver) resolveExpressions(expressions []ast.Expression) {
+	for _, expression := range expressions {
+		r.resolve(expression)
+	}
+}
+
+func (r *Resolver) resolve(node ast.Node) {
+	switch node := node.(type) {
+	case *ast.ExpressionStatement:
+		r.resolve(node.Expression)
+	case *ast.ReturnStatement:
+		r.resolve(node.ReturnValue)
+	case *ast.AssignStatement:
+		r.resolveAssignStatement(node)
+	case *ast.EnumStatement:
+		r.declare(node.Name, false).enum = true
+	case *ast.ImportStatement:
+		r.resolveImportStatement(node)
+	case *ast.BlockStatement:
+		r.hoist(node.Statements, false)
+		r.resolveStatements(node.Statements)
+	case *ast.Identifier:
+		r.resolveReference(node)
+	case *ast.PrefixExpression:
+		r.resolve(node.Right)
+	case *ast.InfixExpression:
+		r.resolve(node.Left)
+		r.resolve(node.Right)
+	case *ast.PostfixExpression:
+		r.resolveAssignment(node.Name)
+	case *ast.ConditionalExpression:
+		r.resolve(node.Condition)
+		r.resolve(node.Consequence)
+		r.resolve(node.Alternative)
+	case *ast.IfExpression:
+		r.resolve(node.Condition)
+		r.resolveBlock(node.Consequence)
+		for _, elif := range node.Elif {
+			r.resolve(elif.Condition)
+			r.resolveBlock(elif.Consequence)
+		}
+		if node.Else != nil {
+			r.resolveBlock(node.Else)
+		}
+	case *ast.ForLoopExpression:
+		r.resolve(node.Condition)
+		r.resolveBlock(node.Consequence)
+	case *ast.ForeachStatement:
+		r.resolveForeachStatement(node)
+	case *ast.FunctionLiteral:
+		r.resolveFunctionLiteral(node)
+	case *ast.CallExpression:
+		if node.Function.TokenLiteral() == "quote" {
+			r.resolveUnquoteCalls(node)
+			return
+		}
+		r.resolve(node.Function)
+		r.resolveExpressions(node.Arguments)
+	case *ast.ObjectCallExpression:
+		r.resolve(node.Object)
+		if call, ok := node.Call.(*ast.CallExpression); ok {
+			r.resolveExpressions(call.Arguments)
+		}
+	case *ast.NamedArgument:
+		r.resolve(node.Value)
+	case *ast.SpreadExpression:
+		r.resolve(node.Value)
+	case *ast.YieldExpression:
+		r.resolve(node.Value)
+	case *ast.PipelineExpression:
+		r.resolvePipelineExpression(node)
+	case *ast.ArrayLiteral:
+		r.resolveExpressions(node.Elements)
+	case *ast.HashLiteral:
+		for _, pair := range node.Pairs {
+			r.resolve(pair.Key)
+			r.resolve(pair.Value)
+		}
+	case *ast.IndexExpression:
+		r.resolve(node.Left)
+		r.resolve(node.Index)
+	case *ast.SliceExpression:
+		r.resolve(node.Left)
+		r.resolve(node.Start)
+		r.resolve(node.Stop)
+		r.resolve(node.Step)
+	case *ast.SwitchExpression:
+		r.resolveSwitchExpression(node)
+	case *ast.MatchExpression:
+		r.resolveMatchExpression(node)
+	}
+}
+
+func (r *Resolver) resolveAssignStatement(stmt *ast.AssignStatement) {
+	r.resolve(stmt.Value)
+	for _, ident := range assignTargets(stmt) {
+		if stmt.Token.Type == token.MUTATE {
+			r.resolveAssignment(ident)
+		} else {
+			r.declare(ident, stmt.Token.Type == token.CONST)
+		}
+	}
+}
+
+func (r *Resolver) resolveImportStatement(is *ast.ImportStatement) {
+	if is.Program == nil {
+		return
+	}
+	fileName := r.fileName
+	r.fileName = is.Path.Value
+	r.resolveStatements(is.Program.Statements)
+	r.fileName = fileName
+}
+
+func (r *Resolver) resolveForeachStatement(fes *ast.ForeachStatement) {
+	r.resolve(fes.Value)
+	r.pushScope(false)
+	if fes.Pattern != nil {
+		r.defineNames(fes.Pattern)
+	} else {
+		r.define(fes.Identifier)
+	}
+	if fes.Index != nil && fes.Index.Value != "" {
+		r.define(fes.Index)
+	}
+	r.hoist(fes.Body.Statements, false)
+	r.resolveStatements(fes.Body.Statements)
+	r.popScope()
+}
+
+func (r *Resolver) resolveFunctionLiteral(fl *ast.FunctionLiteral) {
+	r.pushScope(true)
+	for _, param := range fl.Parameters {
+		if param.Default != nil {
+			r.resolve(param.Default)
+		}
+		r.defineNames(param.Target)
+	}
+	r.hoist(fl.Body.Statements, false)
+	r.resolveStatements(fl.Body.Statements)
+	r.popScope()
+}
+
+func (r *Resolver) resolveUnquoteCalls(quoted ast.Node) {
+	ast.Modify(quoted, func(node ast.Node) ast.Node {
+		call, ok := node.(*ast.CallExpression)
+		if ok && call.Function.TokenLiteral() == "unquote" {
+			r.resolveExpressions(call.Arguments)
+		}
+		return node
+	})
+}
+
+func (r *Resolver) resolvePipelineExpression(pe *ast.PipelineExpression) {
+	r.resolve(pe.Left)
+	call, ok := pe.Right.(*ast.CallExpression)
+	if !ok {
+		r.resolve(pe.Right)
+		return
+	}
+	r.resolve(call.Function)
+	if !evaluator.HasPipelinePlaceholder(call.Arguments) {
+		r.resolveExpressions(call.Arguments)
+		return
+	}
+
+	r.pushScope(false)
+	r.scope.bindings[evaluator.PIPELINE_PLACEHOLDER] = &binding{slot: -1, declared: true}
+	r.resolveExpressions(call.Arguments)
+	r.popScope()
+}
+
+func (r *Resolver) resolveSwitchExpression(se *ast.SwitchExpression) {
+	r.resolve(se.Value)
+	for _, choice := range se.Choices {
+		if choice.Default != nil {
+			r.resolveBlock(choice.Block)
+			continue
+		}
+
+		if choice.Pattern == nil || !r.isEnum(choice.Pattern.Enum) {
+			r.resolve(choice.Expr)
+			r.resolveBlock(choice.Block)
+			continue
+		}
+
+		r.resolvePatternEnums(choice.Pattern)
+		r.pushScope(false)
+		r.defineNames(choice.Pattern)
+		r.hoist(choice.Block.Statements, false)
+		r.resolveStatements(choice.Block.Statements)
+		r.popScope()
+	}
+}
+
+func (r *Resolver) isEnum(ident *ast.Identifier) bool {
+	b, _, _ := r.find(ident.Value)
+	return b != nil && b.enum
+}
+
+func (r *Resolver) resolvePatternEnums(pattern ast.Expression) {
+	switch pattern := pattern.(type) {
+	case *ast.VariantPattern:
+		r.resolve(pattern.Enum)
+		for _, field := range pattern.Fields {
+			r.resolvePatternEnums(field)
+		}
+	case *ast.ArrayPattern:
+		for _, element := range pattern.Elements {
+			r.resolvePatternEnums(element)
+		}
+	case *ast.HashPattern:
+		for _, pair := range pattern.Pairs {
+			r.resolvePatternEnums(pair.Target)
+		}
+	}
+}
+
+func (r *Resolver) resolveMatchExpression(me *ast.MatchExpression) {
+	r.resolve(me.Subject)
+	for _, arm := range me.Arms {
+		r.resolvePatternEnums(arm.Pattern)
+		r.pushScope(false)
+		r.defineNames(arm.Pattern)
+		if arm.Guard != nil {
+			r.resolve(arm.Guard)
+		}
+		r.hoist(arm.Body.Statements, false)
+		r.resolveStatements(arm.Body.Statements)
+		r.popScope()
+	}
+}
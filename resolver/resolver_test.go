@@ -0,0 +1,208 @@
+package resolver
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/ast"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/evaluator"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/file"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/lexer"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/object"
+	"github.com/Jonak-Adipta-Kalita/JAK-Programming-Language/parser"
+)
+
+func parseProgram(t *testing.T, input string) *ast.Program {
+	t.Helper()
+	file.SetFileName("test.jak")
+	p := parser.New(lexer.New(input))
+	program := p.ParseProgram()
+	if errs := p.Errors(); len(errs) != 0 {
+		t.Fatalf("%q: unexpected parser errors: %v", input, errs)
+	}
+	return program
+}
+
+func resolveErrors(t *testing.T, input string) []string {
+	t.Helper()
+	r := New()
+	r.Resolve(parseProgram(t, input))
+	return r.Errors()
+}
+
+func captureOutput(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = w, w
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+	defer func() {
+		os.Stdout, os.Stderr = stdout, stderr
+	}()
+	fn()
+	w.Close()
+	return <-done
+}
+
+func TestResolveErrors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"if (false) { println(undefinedThing); }", "File: test.jak: Line 1: identifier not found: undefinedThing"},
+		{"var f = func() { return missing; };", "identifier not found: missing"},
+		{"var x = 1;\nvar x = 2;", "File: test.jak: Line 2: Variable `x` already defined"},
+		{"var x = 1; var f = func() { var x = 2; };", "Variable `x` already defined"},
+		{"var f = func() { println(y); var y = 1; };", "`y` used before its declaration"},
+		{"mut nothing = 1;", "Variable `nothing` not defined"},
+		{"const c = 1; mut c = 2;", "Cannot assign to constant `c`"},
+		{"const c = 1; var f = func() { c++; };", "Cannot assign to constant `c`"},
+		{"match (1) { [a, a] => a }", "Variable `a` bound more than once"},
+		{"match (1) { Missing.Some(v) => v }", "identifier not found: Missing"},
+	}
+	for _, tt := range tests {
+		errs := resolveErrors(t, tt.input)
+		found := false
+		for _, err := range errs {
+			found = found || strings.Contains(err, tt.expected)
+		}
+		if !found {
+			t.Errorf("%q: got errors %v, want one containing %q", tt.input, errs, tt.expected)
+		}
+	}
+}
+
+func TestResolveValidPrograms(t *testing.T) {
+	tests := []string{
+		"println(len([1]));",
+		"var f = func() { return g(); }; var g = func() { return 1; };",
+		"var f = func(a, b = a, ...rest) { return [a, b, rest]; };",
+		"var [a, {b, ...c}] = [1, {\"b\": 2}];",
+		"foreach i, v in [1] { println(i + v); } foreach i, v in [2] { println(i + v); }",
+		"if (true) { var t = 1; } else { var t = 2; }",
+		"var sub = (a, b) => a - b; println(5 |> sub(10, _));",
+		"enum Opt { Some(v), None }; match (Opt.None) { Opt.Some(v) => v, Opt.None => 0 };",
+		"enum Opt { Some(v) }; switch (Opt.Some(1)) { case Opt.Some(v) { println(v); } }",
+		"var make = func() { var n = 0; return func() { mut n = n + 1; return n; }; };",
+	}
+	for _, input := range tests {
+		if errs := resolveErrors(t, input); len(errs) != 0 {
+			t.Errorf("%q: unexpected resolver errors: %v", input, errs)
+		}
+	}
+}
+
+func TestResolvedSlots(t *testing.T) {
+	program := parseProgram(t, "var top = 1; var f = func(a) { var b = a; return func() { b + top }; };")
+	New().Resolve(program)
+
+	fn := program.Statements[1].(*ast.AssignStatement).Value.(*ast.FunctionLiteral)
+	decl := fn.Body.Statements[0].(*ast.AssignStatement)
+	inner := fn.Body.Statements[1].(*ast.ReturnStatement).ReturnValue.(*ast.FunctionLiteral)
+	sum := inner.Body.Statements[0].(*ast.ExpressionStatement).Expression.(*ast.InfixExpression)
+
+	tests := []struct {
+		ident    *ast.Identifier
+		resolved bool
+		depth    int
+		slot     int
+	}{
+		{program.Statements[0].(*ast.AssignStatement).Name, false, 0, 0},
+		{decl.Value.(*ast.Identifier), true, 0, 0},
+		{decl.Name, true, 0, 1},
+		{sum.Left.(*ast.Identifier), true, 1, 1},
+		{sum.Right.(*ast.Identifier), false, 0, 0},
+	}
+	for _, tt := range tests {
+		if tt.ident.Resolved != tt.resolved {
+			t.Errorf("%s: resolved = %t, want %t", tt.ident.Value, tt.ident.Resolved, tt.resolved)
+			continue
+		}
+		if tt.resolved && (tt.ident.Depth != tt.depth || tt.ident.Slot != tt.slot) {
+			t.Errorf("%s: got (%d, %d), want (%d, %d)", tt.ident.Value, tt.ident.Depth, tt.ident.Slot, tt.depth, tt.slot)
+		}
+	}
+}
+
+func TestResolvedProgramsRun(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"var f = func(a) { var b = a * 2; return b; }; println(f(2))", "4\n"},
+		{"var make = func() { var n = 0; return func() { mut n = n + 1; return n; }; }; var c = make(); c(); println(c())", "2\n"},
+		{"var fs = []; foreach v in [1, 2] { fs.append(func() { v }); } println(fs.map(f => f()))", "[1, 2]\n"},
+		{"var f = func() { const c = 1; var [a, b] = [c, 2]; return a + b; }; println(f())", "3\n"},
+		{"var f = func(n) { return match (n) { 0 => \"zero\", m if m > 0 => \"positive\", _ => \"negative\" }; }; println(f(3))", "positive\n"},
+		{"enum Opt { Some(v), None }; var get = o => match (o) { Opt.Some(v) => v, Opt.None => 0 }; println(get(Opt.Some(7)))", "7\n"},
+		{"var sub = (a, b) => a - b; var f = func(x) { return x |> sub(10, _); }; println(f(5))", "5\n"},
+	}
+	for _, tt := range tests {
+		program := parseProgram(t, tt.input)
+		r := New()
+		r.Resolve(program)
+		if errs := r.Errors(); len(errs) != 0 {
+			t.Fatalf("%q: unexpected resolver errors: %v", tt.input, errs)
+		}
+		got := captureOutput(t, func() { evaluator.Eval(program, object.NewEnvironment()) })
+		if got != tt.expected {
+			t.Errorf("%q: got output %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestSlottedConstantsAreCheckedAtRuntime(t *testing.T) {
+	tests := []string{
+		"var f = func() { const c = 1; mut c = 2; }; f();",
+		"var f = func() { const c = 1; c++; }; f();",
+		"var f = func() { const [c] = [1]; mut [c] = [2]; }; f();",
+	}
+	for _, input := range tests {
+		program := parseProgram(t, input)
+		New().Resolve(program)
+		got := captureOutput(t, func() { evaluator.Eval(program, object.NewEnvironment()) })
+		if !strings.Contains(got, "Error: `Cannot assign to constant `c``") {
+			t.Errorf("%q: got output %q, want a constant assignment error", input, got)
+		}
+	}
+}
+
+func TestRestoreUndoesFailedDeclarations(t *testing.T) {
+	r := New()
+	r.Resolve(parseProgram(t, "var kept = 1;"))
+	state := r.Save()
+
+	r.Resolve(parseProgram(t, "var dropped = 1; var defined = 2; const kept = missing;"))
+	if len(r.Errors()) == 0 {
+		t.Fatal("expected resolver errors")
+	}
+	r.Restore(state, func(name string) bool { return name == "defined" })
+
+	tests := []struct {
+		input string
+		errs  int
+	}{
+		{"var dropped = 3;", 0},
+		{"var defined = 3;", 1},
+		{"mut kept = 3;", 0},
+	}
+	for _, tt := range tests {
+		state := r.Save()
+		r.Resolve(parseProgram(t, tt.input))
+		if got := len(r.Errors()); got != tt.errs {
+			t.Errorf("%q: got %d errors %v, want %d", tt.input, got, r.Errors(), tt.errs)
+		}
+		r.Restore(state, nil)
+	}
+}
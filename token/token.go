@@ -16,6 +16,7 @@ const (
 	IDENTIFIER = "IDENTIFIER"
 	INT        = "INT"
 	FLOAT      = "FLOAT"
+	DECIMAL    = "DECIMAL"
 	STRING     = "STRING"
 	NULL       = "NULL"
 
@@ -37,17 +38,31 @@ const (
 	AND         = "&&"
 	OR          = "||"
 	CARET       = "^"
+	BIT_AND     = "&"
+	BIT_OR      = "|"
+	TILDE       = "~"
+	SHIFT_LEFT  = "<<"
+	SHIFT_RIGHT = ">>"
 
-	COMMA     = ","
-	SEMICOLON = ";"
-	COLON     = ":"
-	DOT       = "."
-	LPAREN    = "("
-	RPAREN    = ")"
-	LBRACE    = "{"
-	RBRACE    = "}"
-	LBRACKET  = "["
-	RBRACKET  = "]"
+	COMMA      = ","
+	SEMICOLON  = ";"
+	COLON      = ":"
+	DOT        = "."
+	DOT_DOT    = ".."
+	DOT_DOT_EQ = "..="
+	ELLIPSIS   = "..."
+	ARROW      = "=>"
+	PIPE       = "|>"
+	LPAREN     = "("
+	RPAREN     = ")"
+	LBRACE     = "{"
+	RBRACE     = "}"
+	LBRACKET   = "["
+	RBRACKET   = "]"
+
+	QUESTION     = "?"
+	NULLISH      = "??"
+	OPTIONAL_DOT = "?."
 
 	FUNCTION = "FUNCTION"
 	VAR      = "VAR"
@@ -66,6 +81,10 @@ const (
 	CASE     = "CASE"
 	DEFAULT  = "DEFAULT"
 	MACRO    = "MACRO"
+	YIELD    = "YIELD"
+	MATCH    = "MATCH"
+	ENUM     = "ENUM"
+	CONST    = "CONST"
 )
 
 var keywords = map[string]TokenType{
@@ -87,6 +106,10 @@ var keywords = map[string]TokenType{
 	"case":    CASE,
 	"default": DEFAULT,
 	"macro":   MACRO,
+	"yield":   YIELD,
+	"match":   MATCH,
+	"enum":    ENUM,
+	"const":   CONST,
 }
 
 func LookupIdentifier(ident string) TokenType {